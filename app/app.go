@@ -186,6 +186,12 @@ func init() {
 // Name defines the application binary name
 const Name = "evmosd"
 
+// BlockedAddrsAppOption is the app option holding the bech32 addresses that are blocked
+// from receiving funds in addition to the module accounts and precompiles.
+//
+// NOTE: This is solely to be used for testing purposes.
+const BlockedAddrsAppOption = "blocked-addrs"
+
 var (
 	// DefaultNodeHome default home directories for the application daemon
 	DefaultNodeHome string
@@ -403,8 +409,12 @@ func NewEvmos(
 		sdk.GetConfig().GetBech32AccountAddrPrefix(),
 		authAddr,
 	)
+	blockedAddrs := app.BlockedAddrs()
+	for _, addr := range cast.ToStringSlice(appOpts.Get(BlockedAddrsAppOption)) {
+		blockedAddrs[addr] = true
+	}
 	app.BankKeeper = bankkeeper.NewBaseKeeper(
-		appCodec, keys[banktypes.StoreKey], app.AccountKeeper, app.BlockedAddrs(), authAddr,
+		appCodec, keys[banktypes.StoreKey], app.AccountKeeper, blockedAddrs, authAddr,
 	)
 	stakingKeeper := stakingkeeper.NewKeeper(
 		appCodec, keys[stakingtypes.StoreKey], app.AccountKeeper, app.BankKeeper, authAddr,
@@ -999,6 +1009,13 @@ func (app *Evmos) GetMemKey(storeKey string) *storetypes.MemoryStoreKey {
 	return app.memKeys[storeKey]
 }
 
+// GetModuleManager returns the module manager of the app.
+//
+// NOTE: This is solely to be used for testing purposes.
+func (app *Evmos) GetModuleManager() *module.Manager {
+	return app.mm
+}
+
 // GetSubspace returns a param subspace for a given module name.
 //
 // NOTE: This is solely to be used for testing purposes.
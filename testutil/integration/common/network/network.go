@@ -29,7 +29,7 @@ type Network interface {
 	GetValidators() []stakingtypes.Validator
 
 	NextBlock() error
-	NextBlockAfter(duration time.Duration) error
+	NextBlockAfter(duration time.Duration) (int64, time.Time, error)
 
 	// Clients
 	GetAuthClient() authtypes.QueryClient
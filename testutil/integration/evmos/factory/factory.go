@@ -15,6 +15,7 @@ import (
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	testutiltypes "github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/cosmos/gogoproto/proto"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -50,6 +51,11 @@ type TxFactory interface {
 	ExecuteEthTx(privKey cryptotypes.PrivKey, txArgs evmtypes.EvmTxArgs) (abcitypes.ResponseDeliverTx, error)
 	// EstimateGasLimit estimates the gas limit for a tx with the provided address and txArgs
 	EstimateGasLimit(from *common.Address, txArgs *evmtypes.EvmTxArgs) (uint64, error)
+
+	// Delegate, Undelegate and Redelegate broadcast the staking message, commit the block and return the updated delegation
+	Delegate(delegator cryptotypes.PrivKey, valAddr string, amount sdktypes.Coin) (stakingtypes.Delegation, error)
+	Undelegate(delegator cryptotypes.PrivKey, valAddr string, amount sdktypes.Coin) (stakingtypes.Delegation, error)
+	Redelegate(delegator cryptotypes.PrivKey, srcValAddr, dstValAddr string, amount sdktypes.Coin) (stakingtypes.Delegation, error)
 }
 
 var _ TxFactory = (*IntegrationTxFactory)(nil)
@@ -0,0 +1,103 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package factory
+
+import (
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	commonfactory "github.com/evmos/evmos/v16/testutil/integration/common/factory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Delegate delegates the given amount from the account of the private key to the
+// validator with the given address and commits the block.
+// It returns the resulting delegation of the account to the validator.
+func (tf *IntegrationTxFactory) Delegate(delegator cryptotypes.PrivKey, valAddr string, amount sdktypes.Coin) (stakingtypes.Delegation, error) {
+	delAddr := sdktypes.AccAddress(delegator.PubKey().Address())
+	validator, err := sdktypes.ValAddressFromBech32(valAddr)
+	if err != nil {
+		return stakingtypes.Delegation{}, errorsmod.Wrapf(err, "invalid validator address %s", valAddr)
+	}
+
+	msg := stakingtypes.NewMsgDelegate(delAddr, validator, amount)
+	if err := tf.executeStakingMsg(delegator, msg); err != nil {
+		return stakingtypes.Delegation{}, errorsmod.Wrap(err, "failed to delegate")
+	}
+	return tf.getDelegation(delAddr, validator)
+}
+
+// Undelegate undelegates the given amount of the account of the private key from the
+// validator with the given address and commits the block.
+// It returns the remaining delegation of the account to the validator, which has
+// zero shares if the whole delegation was undelegated.
+func (tf *IntegrationTxFactory) Undelegate(delegator cryptotypes.PrivKey, valAddr string, amount sdktypes.Coin) (stakingtypes.Delegation, error) {
+	delAddr := sdktypes.AccAddress(delegator.PubKey().Address())
+	validator, err := sdktypes.ValAddressFromBech32(valAddr)
+	if err != nil {
+		return stakingtypes.Delegation{}, errorsmod.Wrapf(err, "invalid validator address %s", valAddr)
+	}
+
+	msg := stakingtypes.NewMsgUndelegate(delAddr, validator, amount)
+	if err := tf.executeStakingMsg(delegator, msg); err != nil {
+		return stakingtypes.Delegation{}, errorsmod.Wrap(err, "failed to undelegate")
+	}
+	return tf.getDelegation(delAddr, validator)
+}
+
+// Redelegate moves the given amount of the delegation of the account of the private key
+// from the source to the destination validator and commits the block.
+// It returns the resulting delegation of the account to the destination validator.
+func (tf *IntegrationTxFactory) Redelegate(delegator cryptotypes.PrivKey, srcValAddr, dstValAddr string, amount sdktypes.Coin) (stakingtypes.Delegation, error) {
+	delAddr := sdktypes.AccAddress(delegator.PubKey().Address())
+	srcValidator, err := sdktypes.ValAddressFromBech32(srcValAddr)
+	if err != nil {
+		return stakingtypes.Delegation{}, errorsmod.Wrapf(err, "invalid validator address %s", srcValAddr)
+	}
+	dstValidator, err := sdktypes.ValAddressFromBech32(dstValAddr)
+	if err != nil {
+		return stakingtypes.Delegation{}, errorsmod.Wrapf(err, "invalid validator address %s", dstValAddr)
+	}
+
+	msg := stakingtypes.NewMsgBeginRedelegate(delAddr, srcValidator, dstValidator, amount)
+	if err := tf.executeStakingMsg(delegator, msg); err != nil {
+		return stakingtypes.Delegation{}, errorsmod.Wrap(err, "failed to redelegate")
+	}
+	return tf.getDelegation(delAddr, dstValidator)
+}
+
+// executeStakingMsg signs and broadcasts a Cosmos tx with the given staking message,
+// with the gas limit estimated by simulating it, and commits the block.
+// It returns an error if the tx fails, after the block including it is committed.
+func (tf *IntegrationTxFactory) executeStakingMsg(delegator cryptotypes.PrivKey, msg sdktypes.Msg) error {
+	res, err := tf.ExecuteCosmosTx(delegator, commonfactory.CosmosTxArgs{
+		Msgs: []sdktypes.Msg{msg},
+	})
+	if err != nil {
+		return err
+	}
+	if err := tf.network.NextBlock(); err != nil {
+		return errorsmod.Wrap(err, "failed to commit block")
+	}
+	if !res.IsOK() {
+		return fmt.Errorf("tx failed. Code: %d, Logs: %s", res.Code, res.Log)
+	}
+	return nil
+}
+
+// getDelegation queries the delegation of the delegator to the validator, which is
+// returned with zero shares if there is none.
+func (tf *IntegrationTxFactory) getDelegation(delAddr sdktypes.AccAddress, valAddr sdktypes.ValAddress) (stakingtypes.Delegation, error) {
+	res, err := tf.grpcHandler.GetDelegation(delAddr.String(), valAddr.String())
+	if status.Code(err) == codes.NotFound {
+		return stakingtypes.NewDelegation(delAddr, valAddr, sdktypes.ZeroDec()), nil
+	}
+	if err != nil {
+		return stakingtypes.Delegation{}, errorsmod.Wrap(err, "failed to query delegation")
+	}
+	return res.DelegationResponse.Delegation, nil
+}
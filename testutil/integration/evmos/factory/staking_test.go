@@ -0,0 +1,68 @@
+package factory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/evmos/evmos/v16/testutil/integration/evmos/grpc"
+	"github.com/evmos/evmos/v16/testutil/integration/evmos/network"
+	testtx "github.com/evmos/evmos/v16/testutil/tx"
+)
+
+func TestStakingHelpers(t *testing.T) {
+	delAddr, delKey := testtx.NewAccAddressAndKey()
+	nw := network.New(
+		network.WithPreFundedAccounts(delAddr),
+	)
+	tf := New(nw, grpc.NewIntegrationHandler(nw))
+	srcVal := nw.GetValidators()[0].OperatorAddress
+	dstVal := nw.GetValidators()[1].OperatorAddress
+	amount := sdktypes.NewCoin(nw.GetDenom(), sdktypes.NewInt(1e18))
+
+	delegation, err := tf.Delegate(delKey, srcVal, amount)
+	require.NoError(t, err)
+	require.Equal(t, sdktypes.NewDecFromInt(amount.Amount), delegation.Shares)
+
+	delegation, err = tf.Undelegate(delKey, srcVal, sdktypes.NewCoin(nw.GetDenom(), sdktypes.NewInt(4e17)))
+	require.NoError(t, err)
+	require.Equal(t, sdktypes.NewDec(6e17), delegation.Shares)
+
+	delegation, err = tf.Redelegate(delKey, srcVal, dstVal, sdktypes.NewCoin(nw.GetDenom(), sdktypes.NewInt(6e17)))
+	require.NoError(t, err)
+	require.Equal(t, dstVal, delegation.ValidatorAddress)
+	require.Equal(t, sdktypes.NewDec(6e17), delegation.Shares)
+
+	// undelegating the whole delegation returns it with zero shares
+	delegation, err = tf.Undelegate(delKey, dstVal, sdktypes.NewCoin(nw.GetDenom(), sdktypes.NewInt(6e17)))
+	require.NoError(t, err)
+	require.True(t, delegation.Shares.IsZero())
+
+	_, err = tf.Delegate(delKey, srcVal, sdktypes.NewInt64Coin("uatom", 1))
+	require.Error(t, err)
+
+	// the delegation exceeds the balance
+	_, err = tf.Delegate(delKey, srcVal, sdktypes.NewCoin(nw.GetDenom(), network.PrefundedAccountInitialBalance))
+	require.Error(t, err)
+}
+
+func TestNestedExpectBalanceChange(t *testing.T) {
+	delAddr, delKey := testtx.NewAccAddressAndKey()
+	receiver, _ := testtx.NewAccAddressAndKey()
+	nw := network.New(
+		network.WithPreFundedAccounts(delAddr),
+	)
+	tf := New(nw, grpc.NewIntegrationHandler(nw))
+	amount := sdktypes.NewInt(1e18)
+	valAddr := nw.GetValidators()[0].OperatorAddress
+
+	// The fees of the delegation delivered on the inner call are ignored on the outer one
+	err := nw.ExpectBalanceChange(delAddr, nw.GetDenom(), amount.Neg(), func() error {
+		return nw.ExpectBalanceChange(receiver, nw.GetDenom(), sdktypes.ZeroInt(), func() error {
+			_, err := tf.Delegate(delKey, valAddr, sdktypes.NewCoin(nw.GetDenom(), amount))
+			return err
+		})
+	}, network.IgnoreFees())
+	require.NoError(t, err)
+}
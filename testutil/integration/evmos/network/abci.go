@@ -3,25 +3,157 @@
 package network
 
 import (
+	"fmt"
+	"strconv"
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	tmtypes "github.com/cometbft/cometbft/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/evmos/evmos/v16/app"
 )
 
+// EventAttributeKeyHeight is the key of the attribute holding the block height
+// that is added to the events returned by WaitNBlocks.
+const EventAttributeKeyHeight = "block_height"
+
 // NextBlock is a private helper function that runs the EndBlocker logic, commits the changes,
-// updates the header and runs the BeginBlocker
+// updates the header and runs the BeginBlocker. If the network has a clock, the new block
+// time is read from it instead of being increased by the configured block time.
 func (n *IntegrationNetwork) NextBlock() error {
-	return n.NextBlockAfter(time.Second)
+	duration, err := n.nextBlockDuration()
+	if err != nil {
+		return err
+	}
+	_, _, err = n.NextBlockAfter(duration)
+	return err
+}
+
+// nextBlockDuration returns the time elapsed between the current block and the next one.
+// It returns an error if the time read from the network's clock isn't after the current block time.
+func (n *IntegrationNetwork) nextBlockDuration() (time.Duration, error) {
+	if n.cfg.clock == nil {
+		return n.cfg.blockTime, nil
+	}
+
+	blockTime := n.ctx.BlockTime()
+	now := n.cfg.clock()
+	if !now.After(blockTime) {
+		return 0, fmt.Errorf("clock time %s must be after the current block time %s", now, blockTime)
+	}
+	return now.Sub(blockTime), nil
+}
+
+// CommitBlocks advances the network the given amount of blocks, each one after the
+// configured block time. It returns an error if any of the blocks fails.
+func (n *IntegrationNetwork) CommitBlocks(amount int) error {
+	for i := 0; i < amount; i++ {
+		if err := n.NextBlock(); err != nil {
+			return fmt.Errorf("failed to commit block %d of %d: %w", i+1, amount, err)
+		}
+	}
+	return nil
+}
+
+// NextBlockAfter runs the EndBlocker logic, commits the changes, updates the header to have
+// a block time after the given duration and runs the BeginBlocker.
+// It returns the height and time of the new block.
+//
+// NOTE: the epochs module ends at most one epoch per identifier and block, so when the
+// duration crosses several boundaries of the same epoch, a block is committed at each of
+// them but the last one first. The last boundary of each epoch is crossed on the new block,
+// so the returned height accounts for the intermediate blocks only when they are needed.
+//
+// It returns ErrUpgradeNeeded if any of the blocks reaches the height of an upgrade plan
+// without a registered handler.
+func (n *IntegrationNetwork) NextBlockAfter(duration time.Duration) (int64, time.Time, error) {
+	header, _, err := n.advanceBlock(duration)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return header.Height, header.Time, nil
+}
+
+// WaitNBlocks advances the network the given amount of blocks, each one after the
+// configured block time or at the time of the network's clock, and returns the events emitted on the EndBlock and BeginBlock
+// of those blocks. The events are in order of emission and each one has an additional
+// attribute with the height of the block that emitted it.
+func (n *IntegrationNetwork) WaitNBlocks(amount int) ([]abci.Event, error) {
+	if amount < 0 {
+		return nil, fmt.Errorf("invalid amount of blocks %d: must be non-negative", amount)
+	}
+
+	var events []abci.Event
+	for i := 0; i < amount; i++ {
+		duration, err := n.nextBlockDuration()
+		if err != nil {
+			return nil, err
+		}
+		_, blockEvents, err := n.advanceBlock(duration)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, blockEvents...)
+	}
+	return events, nil
 }
 
-// NextBlockAfter is a private helper function that runs the EndBlocker logic, commits the changes,
-// updates the header to have a block time after the given duration and runs the BeginBlocker.
-func (n *IntegrationNetwork) NextBlockAfter(duration time.Duration) error {
+// advanceBlock commits a new block with a block time after the given duration, preceded
+// by the intermediate blocks needed to cross every epoch boundary in between.
+// It returns the header of the new block and the events emitted on all the committed blocks.
+// It returns ErrUpgradeNeeded if any of the blocks reaches the height of an upgrade plan
+// without a registered handler.
+func (n *IntegrationNetwork) advanceBlock(duration time.Duration) (tmproto.Header, []abci.Event, error) {
+	blockTime := n.ctx.BlockTime().Add(duration)
+
+	var events []abci.Event
+	for {
+		boundary, found := n.nextEpochBoundary(blockTime)
+		if !found {
+			break
+		}
+		if err := n.checkUpgradeHalt(); err != nil {
+			return tmproto.Header{}, nil, err
+		}
+		_, blockEvents, err := n.nextBlockAfter(boundary.Sub(n.ctx.BlockTime()))
+		if err != nil {
+			return tmproto.Header{}, nil, err
+		}
+		events = append(events, blockEvents...)
+	}
+
+	if err := n.checkUpgradeHalt(); err != nil {
+		return tmproto.Header{}, nil, err
+	}
+	header, blockEvents, err := n.nextBlockAfter(blockTime.Sub(n.ctx.BlockTime()))
+	if err != nil {
+		return tmproto.Header{}, nil, err
+	}
+	return header, append(events, blockEvents...), nil
+}
+
+// nextBlockAfter runs the EndBlocker logic, commits the changes, updates the header
+// to have a block time after the given duration and runs the BeginBlocker.
+// The validator updates returned by the EndBlocker are applied to the validator set
+// of the block after the new one, as CometBFT does.
+// It returns the header of the new block and the events emitted on the EndBlock
+// of the previous block and on the BeginBlock of the new one, with their heights.
+func (n *IntegrationNetwork) nextBlockAfter(duration time.Duration) (tmproto.Header, []abci.Event, error) {
 	// End block and commit
 	header := n.ctx.BlockHeader()
-	n.app.EndBlocker(n.ctx, abci.RequestEndBlock{Height: header.Height})
+	endBlockRes := n.app.EndBlocker(n.ctx, abci.RequestEndBlock{Height: header.Height})
+	n.recordBlockEvents(header.Height, endBlockRes.Events)
+	events := withHeightAttribute(endBlockRes.Events, header.Height)
 	n.app.Commit()
+	n.runTxResultHooks(header.Height)
+
+	lastValSet := n.valSet
+	if err := n.updateValidatorSets(endBlockRes.ValidatorUpdates); err != nil {
+		return tmproto.Header{}, nil, err
+	}
 
 	// Calculate new block time after duration
 	newBlockTime := header.Time.Add(duration)
@@ -30,9 +162,13 @@ func (n *IntegrationNetwork) NextBlockAfter(duration time.Duration) error {
 	header.Height++
 	header.AppHash = n.app.LastCommitID().Hash
 	header.Time = newBlockTime
-	n.app.BeginBlock(abci.RequestBeginBlock{
-		Header: header,
+	header.ProposerAddress = n.nextProposerAddress()
+	beginBlockRes := n.app.BeginBlock(abci.RequestBeginBlock{
+		Header:         header,
+		LastCommitInfo: n.lastCommitInfo(lastValSet),
 	})
+	n.recordBlockEvents(header.Height, beginBlockRes.Events)
+	events = append(events, withHeightAttribute(beginBlockRes.Events, header.Height)...)
 
 	// Update context header
 	newCtx := n.app.BaseApp.NewContext(false, header)
@@ -45,5 +181,178 @@ func (n *IntegrationNetwork) NextBlockAfter(duration time.Duration) error {
 	newCtx = newCtx.WithBlockGasMeter(sdktypes.NewInfiniteGasMeter())
 
 	n.ctx = newCtx
+	n.refreshValidators()
+	return header, events, nil
+}
+
+// setValidatorSet sets the validator set of the current block. Without validator
+// updates, the next block is signed by the same validators.
+func (n *IntegrationNetwork) setValidatorSet(valSet *tmtypes.ValidatorSet) {
+	n.valSet = valSet
+	n.nextValSet = valSet.CopyIncrementProposerPriority(1)
+}
+
+// updateValidatorSets moves to the validator set of the next block and applies the
+// given validator updates, returned by the EndBlocker of the current block, to the
+// validator set of the block after it. This way, the updates take effect two blocks
+// after the one returning them, as in CometBFT.
+func (n *IntegrationNetwork) updateValidatorSets(updates []abci.ValidatorUpdate) error {
+	nextValSet := n.nextValSet.Copy()
+	if len(updates) > 0 {
+		tmUpdates, err := tmtypes.PB2TM.ValidatorUpdates(updates)
+		if err != nil {
+			return err
+		}
+		if err := nextValSet.UpdateWithChangeSet(tmUpdates); err != nil {
+			return fmt.Errorf("failed to update the validator set: %w", err)
+		}
+	}
+	nextValSet.IncrementProposerPriority(1)
+
+	n.valSet = n.nextValSet
+	n.nextValSet = nextValSet
 	return nil
 }
+
+// refreshValidators updates the network validators with their state on the current
+// block, e.g. so that GetValidators reflects their jailed status and tokens.
+func (n *IntegrationNetwork) refreshValidators() {
+	for i, val := range n.validators {
+		operator, err := sdk.ValAddressFromBech32(val.OperatorAddress)
+		if err != nil {
+			continue
+		}
+		if validator, found := n.app.StakingKeeper.GetValidator(n.ctx, operator); found {
+			n.validators[i] = validator
+		}
+	}
+}
+
+// withHeightAttribute returns a copy of the given events with an additional
+// attribute holding the height of the block that emitted them.
+func withHeightAttribute(events []abci.Event, height int64) []abci.Event {
+	heightEvents := make([]abci.Event, 0, len(events))
+	for _, event := range events {
+		attributes := make([]abci.EventAttribute, 0, len(event.Attributes)+1)
+		attributes = append(attributes, event.Attributes...)
+		attributes = append(attributes, abci.EventAttribute{
+			Key:   EventAttributeKeyHeight,
+			Value: strconv.FormatInt(height, 10),
+		})
+		heightEvents = append(heightEvents, abci.Event{
+			Type:       event.Type,
+			Attributes: attributes,
+		})
+	}
+	return heightEvents
+}
+
+// SignBlockWith sets the validators that don't sign the following blocks. The absent
+// validators are identified by their consensus address, in the same format as the keys
+// of the validator signers. From then on, the votes of all validators are included in
+// the last commit info of each block, so the absent validators accumulate missed blocks.
+// It returns an error if any of the addresses doesn't belong to a network validator.
+func (n *IntegrationNetwork) SignBlockWith(absent []string) error {
+	absentSigners := make(map[string]bool, len(absent))
+	for _, addr := range absent {
+		if _, found := n.valSigners[addr]; !found {
+			return fmt.Errorf("validator %s not found in the validator signers", addr)
+		}
+		absentSigners[addr] = true
+	}
+
+	// The genesis validators have no signing info, which is required to
+	// handle their signatures on the slashing BeginBlocker.
+	for _, val := range n.valSet.Validators {
+		consAddr := sdk.ConsAddress(val.Address)
+		if _, found := n.app.SlashingKeeper.GetValidatorSigningInfo(n.ctx, consAddr); found {
+			continue
+		}
+		signingInfo := slashingtypes.NewValidatorSigningInfo(consAddr, n.ctx.BlockHeight(), 0, time.Unix(0, 0), false, 0)
+		n.app.SlashingKeeper.SetValidatorSigningInfo(n.ctx, consAddr, signingInfo)
+	}
+
+	n.absentSigners = absentSigners
+	return nil
+}
+
+// SetNextProposer sets the validator that proposes the next block. The validator is
+// identified by its consensus address, in the same format as the keys of the validator
+// signers. Afterwards, the proposers rotate again by the validators' priority.
+// It returns an error if the validator is not in the validator set of the next block.
+func (n *IntegrationNetwork) SetNextProposer(valAddr string) error {
+	for _, val := range n.nextValSet.Validators {
+		if val.Address.String() == valAddr {
+			n.nextProposer = val.Address
+			return nil
+		}
+	}
+	return fmt.Errorf("validator %s not found in the active validator set", valAddr)
+}
+
+// nextProposerAddress returns the address of the validator that proposes the current
+// block. If no proposer was set with SetNextProposer, the proposers rotate
+// deterministically by the validators' voting power, in the same way as CometBFT does.
+func (n *IntegrationNetwork) nextProposerAddress() []byte {
+	proposer := n.nextProposer
+	n.nextProposer = nil
+	if proposer != nil && n.valSet.HasAddress(proposer) {
+		return proposer
+	}
+	return n.valSet.GetProposer().Address
+}
+
+// lastCommitInfo returns the last commit info with the votes of the validators of the
+// given validator set, which signed the last block.
+// The votes are only included after the signers were set with SignBlockWith.
+func (n *IntegrationNetwork) lastCommitInfo(lastValSet *tmtypes.ValidatorSet) abci.CommitInfo {
+	if n.absentSigners == nil {
+		return abci.CommitInfo{}
+	}
+
+	votes := make([]abci.VoteInfo, 0, len(lastValSet.Validators))
+	for _, val := range lastValSet.Validators {
+		votes = append(votes, abci.VoteInfo{
+			Validator: abci.Validator{
+				Address: val.Address,
+				Power:   val.VotingPower,
+			},
+			SignedLastBlock: !n.absentSigners[val.Address.String()],
+		})
+	}
+	return abci.CommitInfo{Votes: votes}
+}
+
+// disableModuleHooks removes the modules with the given names from the BeginBlock and
+// EndBlock execution order of the given app's module manager, so that their BeginBlock
+// and EndBlock logic is skipped on every block.
+// It returns an error if any of the names is not a module of the app.
+func disableModuleHooks(evmosApp *app.Evmos, moduleNames []string) error {
+	if len(moduleNames) == 0 {
+		return nil
+	}
+
+	mm := evmosApp.GetModuleManager()
+	disabled := make(map[string]bool, len(moduleNames))
+	for _, name := range moduleNames {
+		if _, found := mm.Modules[name]; !found {
+			return fmt.Errorf("cannot disable the hooks of module %s: module not found", name)
+		}
+		disabled[name] = true
+	}
+
+	mm.OrderBeginBlockers = filterModuleNames(mm.OrderBeginBlockers, disabled)
+	mm.OrderEndBlockers = filterModuleNames(mm.OrderEndBlockers, disabled)
+	return nil
+}
+
+// filterModuleNames returns a copy of the given module names without the excluded ones
+func filterModuleNames(moduleNames []string, excluded map[string]bool) []string {
+	filtered := make([]string, 0, len(moduleNames))
+	for _, name := range moduleNames {
+		if !excluded[name] {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,71 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"errors"
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/evmos/evmos/v16/app"
+)
+
+// ErrUnknownModule is returned when querying the address of a module
+// that has no module account on the network.
+var ErrUnknownModule = errors.New("unknown module account")
+
+// ModuleAddress returns the address of the module account with the given name.
+// It returns ErrUnknownModule if the network has no module account with that name.
+func (n *IntegrationNetwork) ModuleAddress(name string) (sdktypes.AccAddress, error) {
+	if _, found := app.GetMaccPerms()[name]; !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownModule, name)
+	}
+	return authtypes.NewModuleAddress(name), nil
+}
+
+// FeeCollectorAddress returns the address of the fee collector module account
+func (n *IntegrationNetwork) FeeCollectorAddress() sdktypes.AccAddress {
+	return authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+}
+
+// BondedPoolAddress returns the address of the bonded pool module account
+func (n *IntegrationNetwork) BondedPoolAddress() sdktypes.AccAddress {
+	return authtypes.NewModuleAddress(stakingtypes.BondedPoolName)
+}
+
+// NotBondedPoolAddress returns the address of the not bonded pool module account
+func (n *IntegrationNetwork) NotBondedPoolAddress() sdktypes.AccAddress {
+	return authtypes.NewModuleAddress(stakingtypes.NotBondedPoolName)
+}
+
+// DistributionAddress returns the address of the distribution module account
+func (n *IntegrationNetwork) DistributionAddress() sdktypes.AccAddress {
+	return authtypes.NewModuleAddress(distrtypes.ModuleName)
+}
+
+// IsBlockedAddress returns true if the given address is blocked from receiving funds,
+// i.e. if it is a module account or a precompile address.
+func (n *IntegrationNetwork) IsBlockedAddress(addr sdktypes.AccAddress) bool {
+	return n.app.BankKeeper.BlockedAddr(addr)
+}
+
+// GetAllBalances returns all the balances of the given address on the current state
+func (n *IntegrationNetwork) GetAllBalances(addr sdktypes.AccAddress) sdktypes.Coins {
+	return n.app.BankKeeper.GetAllBalances(n.ctx, addr)
+}
+
+// IterateAccounts iterates over all the accounts on the current state and calls the given
+// callback for each one. The iteration stops when the callback returns true.
+// The callback must not modify the accounts.
+func (n *IntegrationNetwork) IterateAccounts(cb func(account authtypes.AccountI) (stop bool)) {
+	n.app.AccountKeeper.IterateAccounts(n.ctx, cb)
+}
+
+// SpendableBalance returns the balance of the given address in the given denom that is
+// not locked on the current state, e.g. by the vesting schedule of a vesting account.
+func (n *IntegrationNetwork) SpendableBalance(addr sdktypes.AccAddress, denom string) sdktypes.Coin {
+	return n.app.BankKeeper.SpendableCoin(n.ctx, addr, denom)
+}
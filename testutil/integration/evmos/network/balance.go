@@ -0,0 +1,105 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// balanceChangeConfig defines how the balance change of ExpectBalanceChange is calculated
+type balanceChangeConfig struct {
+	ignoreFees bool
+}
+
+// BalanceChangeOption defines a function that modifies how the balance change is calculated
+type BalanceChangeOption func(*balanceChangeConfig)
+
+// IgnoreFees excludes the fees paid by the address on the transactions delivered during
+// the action from the balance change, so that the delta only holds the action's effect.
+func IgnoreFees() BalanceChangeOption {
+	return func(cfg *balanceChangeConfig) {
+		cfg.ignoreFees = true
+	}
+}
+
+// ExpectBalanceChange runs the given action and checks that the balance of the given
+// address in the given denom changed by the exact delta. The action can deliver
+// transactions and advance blocks.
+// It returns an error if the action fails or if the balance change differs from the delta.
+func (n *IntegrationNetwork) ExpectBalanceChange(addr sdktypes.AccAddress, denom string, delta sdkmath.Int, action func() error, opts ...BalanceChangeOption) error {
+	cfg := balanceChangeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	before := n.app.BankKeeper.GetBalance(n.ctx, addr, denom)
+
+	// NOTE: the responses recorded by an outer call are restored with the ones
+	// of the action appended, so that nested calls don't lose any of them.
+	outerTxResponses := n.txResponses
+	n.txResponses = []abcitypes.ResponseDeliverTx{}
+	err := action()
+	txResponses := n.txResponses
+	n.txResponses = nil
+	if outerTxResponses != nil {
+		n.txResponses = append(outerTxResponses, txResponses...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run action: %w", err)
+	}
+
+	after := n.app.BankKeeper.GetBalance(n.ctx, addr, denom)
+	change := after.Amount.Sub(before.Amount)
+	if cfg.ignoreFees {
+		change = change.Add(paidFees(txResponses, addr, denom))
+	}
+
+	if !change.Equal(delta) {
+		return fmt.Errorf("unexpected balance change of %s for %s: expected %s, got %s", denom, addr, delta, change)
+	}
+	return nil
+}
+
+// paidFees returns the net amount of the given denom that the given address transferred
+// to the fee collector on the given transactions, i.e. the fees deducted minus the refunds.
+func paidFees(txResponses []abcitypes.ResponseDeliverTx, addr sdktypes.AccAddress, denom string) sdkmath.Int {
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName).String()
+	fees := sdkmath.ZeroInt()
+	for _, res := range txResponses {
+		for _, event := range res.Events {
+			if event.Type != banktypes.EventTypeTransfer {
+				continue
+			}
+
+			var sender, recipient, amount string
+			for _, attr := range event.Attributes {
+				switch attr.Key {
+				case sdktypes.AttributeKeySender:
+					sender = attr.Value
+				case banktypes.AttributeKeyRecipient:
+					recipient = attr.Value
+				case sdktypes.AttributeKeyAmount:
+					amount = attr.Value
+				}
+			}
+
+			coins, err := sdktypes.ParseCoinsNormalized(amount)
+			if err != nil {
+				continue
+			}
+			switch {
+			case sender == addr.String() && recipient == feeCollector:
+				fees = fees.Add(coins.AmountOf(denom))
+			case sender == feeCollector && recipient == addr.String():
+				fees = fees.Sub(coins.AmountOf(denom))
+			}
+		}
+	}
+	return fees
+}
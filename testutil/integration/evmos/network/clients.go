@@ -8,6 +8,8 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -88,3 +90,41 @@ func (n *IntegrationNetwork) GetStakingClient() stakingtypes.QueryClient {
 	stakingtypes.RegisterQueryServer(queryHelper, stakingkeeper.Querier{Keeper: &n.app.StakingKeeper})
 	return stakingtypes.NewQueryClient(queryHelper)
 }
+
+// QueryClients holds the query clients of the modules most used on integration tests.
+type QueryClients struct {
+	Bank         banktypes.QueryClient
+	Staking      stakingtypes.QueryClient
+	Evm          evmtypes.QueryClient
+	Distribution distrtypes.QueryClient
+	Gov          govtypes.QueryClient
+}
+
+// GRPCClient returns the query clients for the state committed on the latest block.
+func (n *IntegrationNetwork) GRPCClient() (QueryClients, error) {
+	return n.GRPCClientAtHeight(n.app.LastBlockHeight())
+}
+
+// GRPCClientAtHeight returns the query clients for the state committed at the given
+// block height. It returns an error if the height is not a committed height.
+func (n *IntegrationNetwork) GRPCClientAtHeight(height int64) (QueryClients, error) {
+	ctx, err := n.GetContextAtHeight(height)
+	if err != nil {
+		return QueryClients{}, err
+	}
+
+	queryHelper := getQueryHelper(ctx)
+	banktypes.RegisterQueryServer(queryHelper, n.app.BankKeeper)
+	stakingtypes.RegisterQueryServer(queryHelper, stakingkeeper.Querier{Keeper: &n.app.StakingKeeper})
+	evmtypes.RegisterQueryServer(queryHelper, n.app.EvmKeeper)
+	distrtypes.RegisterQueryServer(queryHelper, distrkeeper.NewQuerier(n.app.DistrKeeper))
+	govtypes.RegisterQueryServer(queryHelper, n.app.GovKeeper)
+
+	return QueryClients{
+		Bank:         banktypes.NewQueryClient(queryHelper),
+		Staking:      stakingtypes.NewQueryClient(queryHelper),
+		Evm:          evmtypes.NewQueryClient(queryHelper),
+		Distribution: distrtypes.NewQueryClient(queryHelper),
+		Gov:          govtypes.NewQueryClient(queryHelper),
+	}, nil
+}
@@ -4,25 +4,97 @@ package network
 
 import (
 	"math/big"
+	"time"
 
+	"github.com/evmos/evmos/v16/app"
 	testtx "github.com/evmos/evmos/v16/testutil/tx"
 	"github.com/evmos/evmos/v16/utils"
 
+	"cosmossdk.io/simapp"
+	"github.com/cometbft/cometbft/libs/log"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	tmtypes "github.com/cometbft/cometbft/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
-	evmostypes "github.com/evmos/evmos/v16/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1types "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	epochstypes "github.com/evmos/evmos/v16/x/epochs/types"
+	evmtypes "github.com/evmos/evmos/v16/x/evm/types"
 )
 
 // Config defines the configuration for a chain.
 // It allows for customization of the network to adjust to
 // testing needs.
 type Config struct {
-	chainID            string
-	eip155ChainID      *big.Int
-	amountOfValidators int
-	preFundedAccounts  []sdktypes.AccAddress
-	denom              string
+	chainID                  string
+	eip155ChainID            *big.Int
+	amountOfValidators       int
+	noValidators             bool
+	validatorPowers          []int64
+	validatorCommissions     []stakingtypes.CommissionRates
+	jailedValidators         []int
+	preFundedAccounts        []sdktypes.AccAddress
+	preFundedCoins           sdktypes.Coins
+	denom                    string
+	customGenesisState       CustomGenesisState
+	evmContracts             map[common.Address]GenesisContract
+	vestingAccounts          []VestingAccount
+	genesisTime              time.Time
+	blockTime                time.Duration
+	clock                    func() time.Time
+	consensusParams          *tmproto.ConsensusParams
+	delegations              []stakingtypes.Delegation
+	unbondingDelegations     []stakingtypes.UnbondingDelegation
+	redelegations            []stakingtypes.Redelegation
+	unbondingTime            time.Duration
+	maxValidators            uint32
+	maxEntries               uint32
+	historicalEntries        *uint32
+	validatorPrivVals        []tmtypes.PrivValidator
+	accountSequences         []uint64
+	accountNumbers           []uint64
+	evmDisabled              bool
+	homePath                 string
+	inflationEnabled         bool
+	inflationEpochIdentifier string
+	inflationEpochsPerPeriod int64
+	epochs                   []epochstypes.EpochInfo
+	nativeErc20Pairs         []NativeErc20Pair
+	logger                   log.Logger
+	denomMetadata            []banktypes.Metadata
+	sendEnabled              []banktypes.SendEnabled
+	blockGasWanted           uint64
+	communityPool            sdktypes.DecCoins
+	feeCollectorBalance      sdktypes.Coins
+	blockedAddresses         []sdktypes.AccAddress
+	customPrecompiles        map[common.Address]vm.PrecompiledContract
+	govParams                *govv1types.Params
+	genesisMutators          []func(simapp.GenesisState) simapp.GenesisState
+	evmParamsModifiers       []func(*evmtypes.Params)
+	upgradePlan              *upgradetypes.Plan
+	upgradeHandler           upgradetypes.UpgradeHandler
+	anteHandler              sdktypes.AnteHandler
+	signedBlocksWindow       int64
+	minSignedPerWindow       *sdktypes.Dec
+	withdrawAddresses        []distrtypes.DelegatorWithdrawInfo
+	initialHeight            int64
+	txResultHooks            []TxResultHook
+	minGasPrices             sdktypes.DecCoins
+	erc20Precompiles         []string
+	moduleAccounts           []ModuleAccount
+	disabledModuleHooks      []string
+	depositPeriodProposals   []depositPeriodProposal
 }
 
+// CustomGenesisState defines a map from a module name to a custom genesis
+// state for that module. It allows to overwrite the default genesis state
+// used by the network for specific modules.
+type CustomGenesisState map[string]interface{}
+
 // DefaultConfig returns the default configuration for a chain.
 func DefaultConfig() Config {
 	account, _ := testtx.NewAccAddressAndKey()
@@ -33,6 +105,10 @@ func DefaultConfig() Config {
 		// No funded accounts besides the validators by default
 		preFundedAccounts: []sdktypes.AccAddress{account},
 		denom:             utils.BaseDenom,
+		blockTime:         time.Second,
+		initialHeight:     1,
+		consensusParams:   app.DefaultConsensusParams,
+		logger:            log.NewNopLogger(),
 	}
 }
 
@@ -41,15 +117,11 @@ func DefaultConfig() Config {
 // requires to be changed.
 type ConfigOption func(*Config)
 
-// WithChainID sets a custom chainID for the network. It panics if the chainID is invalid.
+// WithChainID sets a custom chainID for the network. The chainID must follow the
+// Evmos format with a valid EIP-155 number, otherwise the network setup fails.
 func WithChainID(chainID string) ConfigOption {
-	chainIDNum, err := evmostypes.ParseChainID(chainID)
-	if err != nil {
-		panic(err)
-	}
 	return func(cfg *Config) {
 		cfg.chainID = chainID
-		cfg.eip155ChainID = chainIDNum
 	}
 }
 
@@ -60,6 +132,47 @@ func WithAmountOfValidators(amount int) ConfigOption {
 	}
 }
 
+// WithNoValidators sets up a network without validators for tests that don't depend
+// on consensus, e.g. bank or auth logic.
+//
+// NOTE: CometBFT requires at least one validator, so the network runs a single hidden
+// validator with a voting power of 1 that produces the blocks. It is not returned by
+// GetValidators, but its bonded tokens are part of the total supply and it receives the
+// block rewards. It cannot be combined with other validator options.
+func WithNoValidators() ConfigOption {
+	return func(cfg *Config) {
+		cfg.noValidators = true
+		cfg.amountOfValidators = 1
+	}
+}
+
+// WithValidatorPowers sets a custom voting power for each of the network's validators.
+// The amount of validators is set to the amount of powers provided.
+func WithValidatorPowers(powers ...int64) ConfigOption {
+	return func(cfg *Config) {
+		cfg.amountOfValidators = len(powers)
+		cfg.validatorPowers = powers
+	}
+}
+
+// WithValidatorCommissions sets the commission rates for the network's validators.
+// If fewer commission rates than validators are provided, the last entry is used
+// for the remaining validators.
+func WithValidatorCommissions(commissionRates ...stakingtypes.CommissionRates) ConfigOption {
+	return func(cfg *Config) {
+		cfg.validatorCommissions = commissionRates
+	}
+}
+
+// WithJailedValidators sets the validators that are jailed at genesis. The indices
+// refer to the position of the validators in the network's validator set.
+// Jailed validators are unbonded and excluded from the block signers.
+func WithJailedValidators(indices ...int) ConfigOption {
+	return func(cfg *Config) {
+		cfg.jailedValidators = indices
+	}
+}
+
 // WithPreFundedAccounts sets the pre-funded accounts for the network.
 func WithPreFundedAccounts(accounts ...sdktypes.AccAddress) ConfigOption {
 	return func(cfg *Config) {
@@ -67,9 +180,457 @@ func WithPreFundedAccounts(accounts ...sdktypes.AccAddress) ConfigOption {
 	}
 }
 
+// WithDeterministicPreFundedAccounts sets the given amount of pre-funded accounts with
+// addresses derived from the given seed, so that the accounts are stable across runs.
+// The private keys of the accounts are returned by DeterministicAccounts with the same
+// seed and amount.
+func WithDeterministicPreFundedAccounts(seed int64, amount int) ConfigOption {
+	accounts, _ := DeterministicAccounts(seed, amount)
+	return WithPreFundedAccounts(accounts...)
+}
+
+// WithPreFundedAccountsMeta sets the sequences and account numbers of the pre-funded
+// accounts at genesis. One sequence and one unique account number must be provided
+// for each of the pre-funded accounts.
+func WithPreFundedAccountsMeta(seqs, numbers []uint64) ConfigOption {
+	return func(cfg *Config) {
+		cfg.accountSequences = seqs
+		cfg.accountNumbers = numbers
+	}
+}
+
+// WithPreFundedCoins sets the coins that each of the pre-funded accounts holds at genesis.
+// When not set, the accounts are funded only with the network's denom.
+func WithPreFundedCoins(coins ...sdktypes.Coin) ConfigOption {
+	return func(cfg *Config) {
+		cfg.preFundedCoins = sdktypes.NewCoins(coins...)
+	}
+}
+
 // WithDenom sets the denom for the network.
 func WithDenom(denom string) ConfigOption {
 	return func(cfg *Config) {
 		cfg.denom = denom
 	}
 }
+
+// WithCustomGenesis sets the custom genesis states of the network for specific modules.
+func WithCustomGenesis(customGenesis CustomGenesisState) ConfigOption {
+	return func(cfg *Config) {
+		cfg.customGenesisState = customGenesis
+	}
+}
+
+// WithEVMContracts sets contracts that are deployed at genesis. Each contract's
+// code and storage is included in the EVM genesis state, and a contract account
+// with the corresponding code hash is created in the auth genesis state.
+func WithEVMContracts(contracts map[common.Address]GenesisContract) ConfigOption {
+	return func(cfg *Config) {
+		cfg.evmContracts = contracts
+	}
+}
+
+// WithVestingAccounts sets clawback vesting accounts that are created at genesis.
+// Each account is funded with its original vesting amount. The vesting accounts
+// must not be included in the pre-funded accounts.
+func WithVestingAccounts(vestingAccounts ...VestingAccount) ConfigOption {
+	return func(cfg *Config) {
+		cfg.vestingAccounts = vestingAccounts
+	}
+}
+
+// WithGenesisTime sets the block time of the network's genesis block. The vesting
+// schedules of the genesis vesting accounts start at this time.
+func WithGenesisTime(genesisTime time.Time) ConfigOption {
+	return func(cfg *Config) {
+		cfg.genesisTime = genesisTime
+	}
+}
+
+// WithDelegations sets the delegations included in the staking genesis. The validators
+// without any of the given delegations are delegated one share from the first funded
+// account. The delegator shares of each validator are the sum of its delegations' shares.
+func WithDelegations(delegations ...stakingtypes.Delegation) ConfigOption {
+	return func(cfg *Config) {
+		cfg.delegations = delegations
+	}
+}
+
+// WithUnbondingDelegations sets the unbonding delegations included in the staking
+// genesis. The entries' balances are held by the not bonded pool and must complete
+// after the genesis time.
+func WithUnbondingDelegations(unbondingDelegations ...stakingtypes.UnbondingDelegation) ConfigOption {
+	return func(cfg *Config) {
+		cfg.unbondingDelegations = unbondingDelegations
+	}
+}
+
+// WithRedelegations sets the redelegations included in the staking genesis.
+// The entries must complete after the genesis time.
+func WithRedelegations(redelegations ...stakingtypes.Redelegation) ConfigOption {
+	return func(cfg *Config) {
+		cfg.redelegations = redelegations
+	}
+}
+
+// WithUnbondingTime sets the unbonding time of the staking params, e.g. a short
+// duration so that the unbonding delegations mature after a few blocks.
+// The unbonding time must be positive.
+func WithUnbondingTime(unbondingTime time.Duration) ConfigOption {
+	return func(cfg *Config) {
+		cfg.unbondingTime = unbondingTime
+	}
+}
+
+// WithMaxValidators sets the max validators of the staking params. It must not be
+// lower than the amount of the network's validators.
+func WithMaxValidators(maxValidators uint32) ConfigOption {
+	return func(cfg *Config) {
+		cfg.maxValidators = maxValidators
+	}
+}
+
+// WithMaxEntries sets the max unbonding delegation and redelegation entries of the
+// staking params.
+func WithMaxEntries(maxEntries uint32) ConfigOption {
+	return func(cfg *Config) {
+		cfg.maxEntries = maxEntries
+	}
+}
+
+// WithHistoricalEntries sets the historical entries of the staking params, which is the
+// amount of past blocks whose headers and validator sets are kept for IBC client
+// verification. It must be positive when a custom IBC genesis state is provided.
+func WithHistoricalEntries(entries uint32) ConfigOption {
+	return func(cfg *Config) {
+		cfg.historicalEntries = &entries
+	}
+}
+
+// WithValidatorPrivVals sets the signers of the network's validators. The amount of
+// validators is set to the amount of signers provided. When combined with
+// WithValidatorPowers, one power must be provided for each signer.
+func WithValidatorPrivVals(privVals ...tmtypes.PrivValidator) ConfigOption {
+	return func(cfg *Config) {
+		cfg.amountOfValidators = len(privVals)
+		cfg.validatorPrivVals = privVals
+	}
+}
+
+// WithDeterministicValidators sets the given amount of validators with signers derived
+// from the given seed, so that the validators' consensus addresses are stable across runs.
+func WithDeterministicValidators(seed int64, amount int) ConfigOption {
+	return WithValidatorPrivVals(deterministicPrivVals(seed, amount)...)
+}
+
+// WithBlockTime sets the time elapsed between the network's blocks.
+func WithBlockTime(blockTime time.Duration) ConfigOption {
+	return func(cfg *Config) {
+		cfg.blockTime = blockTime
+	}
+}
+
+// WithClock sets the time source of the network. The genesis time, if not set, and
+// the time of each new block are read from the clock, which must be monotonic.
+// The block time is ignored when advancing blocks with a clock.
+func WithClock(clock func() time.Time) ConfigOption {
+	return func(cfg *Config) {
+		cfg.clock = clock
+	}
+}
+
+// WithConsensusParams sets the consensus params of the network, such as the
+// block max gas and max bytes.
+func WithConsensusParams(consensusParams tmproto.ConsensusParams) ConfigOption {
+	return func(cfg *Config) {
+		cfg.consensusParams = &consensusParams
+	}
+}
+
+// WithEVMDisabled disables EVM calls and contract creations, and deactivates all
+// precompiles. It is meant for tests that only use Cosmos transactions.
+func WithEVMDisabled() ConfigOption {
+	return func(cfg *Config) {
+		cfg.evmDisabled = true
+	}
+}
+
+// WithHomePath sets the node home path of the network's app. When not set,
+// a unique temporary directory is created for each network, which is removed
+// on Close.
+func WithHomePath(homePath string) ConfigOption {
+	return func(cfg *Config) {
+		cfg.homePath = homePath
+	}
+}
+
+// WithInflationEnabled enables inflation, minting on each epoch with the given identifier.
+// The rest of the inflation genesis, e.g. the exponential calculation params, is kept from
+// the custom inflation genesis if provided. The epoch identifier must be one of the epochs
+// genesis identifiers.
+func WithInflationEnabled(epochIdentifier string, epochsPerPeriod int64) ConfigOption {
+	return func(cfg *Config) {
+		cfg.inflationEnabled = true
+		cfg.inflationEpochIdentifier = epochIdentifier
+		cfg.inflationEpochsPerPeriod = epochsPerPeriod
+	}
+}
+
+// WithEpochs registers the given epochs in addition to the default day and week epochs,
+// e.g. to run epoch hooks on shorter intervals. The epoch identifiers must be unique.
+func WithEpochs(epochs ...epochstypes.EpochInfo) ConfigOption {
+	return func(cfg *Config) {
+		cfg.epochs = epochs
+	}
+}
+
+// WithNativeErc20Pairs registers the given native coins as erc20 token pairs at genesis.
+// The ERC20 contracts are deployed at the addresses the erc20 module derives for them.
+// The coins must have a genesis supply, e.g. funded with WithPreFundedCoins.
+func WithNativeErc20Pairs(pairs ...NativeErc20Pair) ConfigOption {
+	return func(cfg *Config) {
+		cfg.nativeErc20Pairs = pairs
+	}
+}
+
+// WithLogger sets the logger of the network app, e.g. a debug level logger to
+// trace the modules behavior on a failing test. By default, no logs are written.
+func WithLogger(logger log.Logger) ConfigOption {
+	return func(cfg *Config) {
+		cfg.logger = logger
+	}
+}
+
+// WithDenomMetadata sets the denom metadata included in the bank genesis.
+// The base denom of each metadata must have a genesis supply.
+func WithDenomMetadata(metadata ...banktypes.Metadata) ConfigOption {
+	return func(cfg *Config) {
+		cfg.denomMetadata = metadata
+	}
+}
+
+// WithSendEnabled sets the send enabled entries of the bank genesis. The coins of a
+// send disabled denom cannot be transferred, but they can still be delegated.
+func WithSendEnabled(sendEnabled ...banktypes.SendEnabled) ConfigOption {
+	return func(cfg *Config) {
+		cfg.sendEnabled = sendEnabled
+	}
+}
+
+// WithBlockGasWanted sets the gas wanted on the block before the genesis, which the
+// fee market uses to calculate the base fee of the first block. E.g. a value above
+// the gas target (max block gas / elasticity multiplier) makes the base fee rise.
+func WithBlockGasWanted(gasWanted uint64) ConfigOption {
+	return func(cfg *Config) {
+		cfg.blockGasWanted = gasWanted
+	}
+}
+
+// WithCommunityPool sets the community pool of the distribution genesis. The
+// distribution module account is funded with the truncated amount to back it.
+func WithCommunityPool(coins sdktypes.DecCoins) ConfigOption {
+	return func(cfg *Config) {
+		cfg.communityPool = coins
+	}
+}
+
+// WithFeeCollectorBalance sets the balance of the fee collector module account at
+// genesis. The balance is included in the total supply and is allocated by the
+// distribution module on the first block, as the fees of a previous block.
+func WithFeeCollectorBalance(coins sdktypes.Coins) ConfigOption {
+	return func(cfg *Config) {
+		cfg.feeCollectorBalance = coins
+	}
+}
+
+// WithCustomPrecompile registers the given precompile at the given address and activates
+// it, so that the EVM calls to the address are routed to the precompile. The address
+// must match the precompile address.
+func WithCustomPrecompile(addr common.Address, contract vm.PrecompiledContract) ConfigOption {
+	return func(cfg *Config) {
+		if cfg.customPrecompiles == nil {
+			cfg.customPrecompiles = make(map[common.Address]vm.PrecompiledContract)
+		}
+		cfg.customPrecompiles[addr] = contract
+	}
+}
+
+// WithGovParams sets the params of the gov genesis, e.g. a short voting period so
+// that the proposals are tallied after a few blocks. The voting and deposit periods
+// must be positive and the min deposit must be in the bond denom.
+func WithGovParams(params govv1types.Params) ConfigOption {
+	return func(cfg *Config) {
+		cfg.govParams = &params
+	}
+}
+
+// WithGenesisMutator adds a function that modifies the genesis state after all the
+// module genesis states are set and before it is passed to InitChain. It allows to
+// set genesis fields that no other option covers.
+// The mutators run in the order they are added. A mutator that returns a nil
+// genesis state causes the network initialization to fail.
+func WithGenesisMutator(mutator func(simapp.GenesisState) simapp.GenesisState) ConfigOption {
+	return func(cfg *Config) {
+		cfg.genesisMutators = append(cfg.genesisMutators, mutator)
+	}
+}
+
+// WithEVMParams adds a function that modifies the params of the EVM genesis, e.g. to
+// enable extra EIPs or to disable contract creations. The modifiers run in the order
+// they are added. If the EVM denom is changed, it must have bank metadata.
+func WithEVMParams(modifier func(*evmtypes.Params)) ConfigOption {
+	return func(cfg *Config) {
+		cfg.evmParamsModifiers = append(cfg.evmParamsModifiers, modifier)
+	}
+}
+
+// WithEVMChainConfig sets the chain config of the EVM genesis params, e.g. to activate a
+// hard fork at a given block height. The fork heights must be non-negative and follow the
+// order of the Ethereum hard forks.
+func WithEVMChainConfig(chainConfig evmtypes.ChainConfig) ConfigOption {
+	return WithEVMParams(func(params *evmtypes.Params) {
+		params.ChainConfig = chainConfig
+	})
+}
+
+// WithBlockedAddresses blocks the given addresses from receiving funds through the bank
+// keeper, in addition to the module accounts and the precompiles blocked by the app, so
+// that e.g. a MsgSend to any of them is rejected.
+// The pre-funded and vesting accounts cannot be blocked addresses.
+func WithBlockedAddresses(addrs []sdktypes.AccAddress) ConfigOption {
+	return func(cfg *Config) {
+		cfg.blockedAddresses = addrs
+	}
+}
+
+// WithUpgradePlan schedules the given software upgrade plan at genesis. The plan height
+// must be after the genesis block. When the network reaches the plan height, the upgrade
+// module applies the given handler, or the one registered by the app for the plan name
+// if the handler is nil. If neither exists, the network halts and committing the block
+// of the plan height returns ErrUpgradeNeeded.
+func WithUpgradePlan(plan upgradetypes.Plan, handler upgradetypes.UpgradeHandler) ConfigOption {
+	return func(cfg *Config) {
+		cfg.upgradePlan = &plan
+		cfg.upgradeHandler = handler
+	}
+}
+
+// WithAnteHandler replaces the default ante handler of the app with the given one, so
+// that the transactions on CheckTx and DeliverTx are only checked by it.
+//
+// NOTE: the given handler bypasses the whole standard ante handler stack, e.g. the
+// signature verification and the fee deduction, so the test is responsible for
+// including any decorator it relies on.
+func WithAnteHandler(anteHandler sdktypes.AnteHandler) ConfigOption {
+	return func(cfg *Config) {
+		cfg.anteHandler = anteHandler
+	}
+}
+
+// WithSlashingWindow sets the downtime params of the slashing genesis, e.g. a short
+// window so that a validator that doesn't sign the blocks set with SignBlockWith is
+// jailed after a few blocks. The window must be positive and the min signed per
+// window must be positive and at most one.
+func WithSlashingWindow(signedBlocksWindow int64, minSignedPerWindow sdktypes.Dec) ConfigOption {
+	return func(cfg *Config) {
+		cfg.signedBlocksWindow = signedBlocksWindow
+		cfg.minSignedPerWindow = &minSignedPerWindow
+	}
+}
+
+// WithWithdrawAddress sets the address where the distribution rewards of the given
+// delegator are sent at genesis, instead of the delegator address. Both addresses must
+// be pre-funded or vesting accounts.
+func WithWithdrawAddress(delegator, withdrawAddr sdktypes.AccAddress) ConfigOption {
+	return func(cfg *Config) {
+		cfg.withdrawAddresses = append(cfg.withdrawAddresses, distrtypes.DelegatorWithdrawInfo{
+			DelegatorAddress: delegator.String(),
+			WithdrawAddress:  withdrawAddr.String(),
+		})
+	}
+}
+
+// WithInitialHeight sets the height of the genesis block, so that the blocks committed
+// afterwards start from it instead of from the first height. It allows to reproduce the
+// absolute heights of a running chain, e.g. on migrations or IBC client updates.
+// The height must be at least one.
+func WithInitialHeight(height int64) ConfigOption {
+	return func(cfg *Config) {
+		cfg.initialHeight = height
+	}
+}
+
+// WithTxResultHook adds a function that is called with the result of each transaction
+// delivered on the network once its block is committed, e.g. to test the ingestion of an
+// indexer. The transactions of a block are passed in the order they were delivered and
+// the hooks are called in the order they are added.
+func WithTxResultHook(hook TxResultHook) ConfigOption {
+	return func(cfg *Config) {
+		cfg.txResultHooks = append(cfg.txResultHooks, hook)
+	}
+}
+
+// WithMinGasPrices sets the local min gas prices of the node, which are checked on
+// CheckTx in addition to the fee market params, instead of the fee market min gas price.
+// It allows to test that the transactions paying the network base fee but less than
+// the local min gas prices are rejected from the mempool.
+func WithMinGasPrices(minGasPrices sdktypes.DecCoins) ConfigOption {
+	return func(cfg *Config) {
+		cfg.minGasPrices = minGasPrices
+	}
+}
+
+// WithErc20Precompiles registers and activates the ERC20 precompiles of the native coin
+// token pairs with the given contract addresses at genesis, e.g. the ones seeded with
+// WithNativeErc20Pairs, so that the EVM calls to those addresses are routed to the
+// precompiles without a registration transaction. The addresses must be valid hex
+// addresses of registered native coin token pairs.
+func WithErc20Precompiles(addrs ...string) ConfigOption {
+	return func(cfg *Config) {
+		cfg.erc20Precompiles = addrs
+	}
+}
+
+// WithModuleAccount adds a standalone module account with the given name and permissions
+// at genesis, e.g. for a module that is not registered on the app yet. The name must not
+// collide with the module accounts of the app.
+//
+// NOTE: the account keeper only knows the permissions of the app's module accounts, so
+// the bank module functions, e.g. MintCoins, don't recognize the standalone module
+// account. It can still send and receive funds by address.
+func WithModuleAccount(name string, perms ...string) ConfigOption {
+	return func(cfg *Config) {
+		cfg.moduleAccounts = append(cfg.moduleAccounts, ModuleAccount{Name: name, Permissions: perms})
+	}
+}
+
+// WithDisabledModuleHooks skips the BeginBlock and EndBlock logic of the modules with
+// the given names on every block, e.g. the inflation module to keep the supply constant
+// or the epochs module to freeze the epochs. The names must be modules of the app.
+//
+// NOTE: the modules depend on each other's BeginBlock and EndBlock logic, e.g. disabling
+// the staking module stops the validator set updates and the unbonding completions, so
+// the hooks should be disabled with care.
+func WithDisabledModuleHooks(moduleNames ...string) ConfigOption {
+	return func(cfg *Config) {
+		cfg.disabledModuleHooks = moduleNames
+	}
+}
+
+// WithDepositPeriodProposal adds a proposal submitted at genesis by the given depositor
+// that remains in the deposit period with the given partial deposit. Its deposit period
+// ends after the given duration from the genesis time, so a zero duration makes the
+// proposal expire on the first block, when the gov EndBlocker removes it and burns or
+// refunds its deposit as set by the gov params.
+// The depositor must be a genesis account, the deposit must be in the bond denom and
+// lower than the min deposit, and the deposit period cannot exceed the max deposit
+// period of the gov params. The gov module account is funded with the deposit.
+func WithDepositPeriodProposal(depositor sdktypes.AccAddress, deposit sdktypes.Coins, depositPeriod time.Duration) ConfigOption {
+	return func(cfg *Config) {
+		cfg.depositPeriodProposals = append(cfg.depositPeriodProposals, depositPeriodProposal{
+			depositor:     depositor,
+			deposit:       deposit,
+			depositPeriod: depositPeriod,
+		})
+	}
+}
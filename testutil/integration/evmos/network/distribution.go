@@ -0,0 +1,47 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DelegationRewards returns the rewards accumulated by the delegation of the given
+// delegator to the validator with the given operator address, in the same way as the
+// distribution rewards query. The rewards include the ones of the current period of
+// the validator, which is not ended until the delegation changes or is withdrawn.
+// It returns an error if the validator or the delegation are not found.
+func (n *IntegrationNetwork) DelegationRewards(delegator, validator string) (sdktypes.DecCoins, error) {
+	delAddr, err := sdktypes.AccAddressFromBech32(delegator)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delegator address %s: %w", delegator, err)
+	}
+	val, err := n.getStakingValidator(validator)
+	if err != nil {
+		return nil, err
+	}
+
+	// The query ends the current period of the validator, so it runs on a cache
+	// context to leave the state unchanged.
+	ctx, _ := n.ctx.CacheContext()
+	del := n.app.StakingKeeper.Delegation(ctx, delAddr, val.GetOperator())
+	if del == nil {
+		return nil, fmt.Errorf("delegation from %s to %s not found", delegator, validator)
+	}
+
+	endingPeriod := n.app.DistrKeeper.IncrementValidatorPeriod(ctx, val)
+	return n.app.DistrKeeper.CalculateDelegationRewards(ctx, val, del, endingPeriod), nil
+}
+
+// ValidatorCommission returns the commission accumulated by the validator with the
+// given operator address that has not been withdrawn yet.
+// It returns an error if the validator is not found.
+func (n *IntegrationNetwork) ValidatorCommission(valAddr string) (sdktypes.DecCoins, error) {
+	val, err := n.getStakingValidator(valAddr)
+	if err != nil {
+		return nil, err
+	}
+	return n.app.DistrKeeper.GetValidatorAccumulatedCommission(n.ctx, val.GetOperator()).Commission, nil
+}
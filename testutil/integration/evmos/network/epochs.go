@@ -0,0 +1,88 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+	"time"
+
+	epochstypes "github.com/evmos/evmos/v16/x/epochs/types"
+)
+
+// AdvanceToNextEpoch commits the current block and begins a new one right after the end of
+// the current epoch with the given identifier, so that exactly one epoch boundary of it is
+// crossed. If the epoch counting has not started, the new block begins at the epoch start
+// time. If the epoch end is already past, the new block follows the configured block time.
+// It returns the number of the epoch that starts on the new block.
+//
+// NOTE: the block time is set from the epoch schedule, so the network's clock is not used.
+//
+// It returns an error if no epoch with the given identifier is registered.
+func (n *IntegrationNetwork) AdvanceToNextEpoch(identifier string) (int64, error) {
+	epochInfo, found := n.app.EpochsKeeper.GetEpochInfo(n.ctx, identifier)
+	if !found {
+		return 0, fmt.Errorf("epoch %s is not registered", identifier)
+	}
+
+	// NOTE: the epoch ends on the first block whose time is after the epoch end time
+	boundary := epochInfo.CurrentEpochStartTime.Add(epochInfo.Duration).Add(time.Nanosecond)
+	if !epochInfo.EpochCountingStarted {
+		boundary = epochInfo.StartTime
+	}
+
+	duration := boundary.Sub(n.ctx.BlockTime())
+	if duration <= 0 {
+		duration = n.cfg.blockTime
+	}
+	if _, _, err := n.NextBlockAfter(duration); err != nil {
+		return 0, err
+	}
+
+	newEpochInfo, _ := n.app.EpochsKeeper.GetEpochInfo(n.ctx, identifier)
+	if newEpochInfo.CurrentEpoch != epochInfo.CurrentEpoch+1 {
+		return 0, fmt.Errorf("epoch %s didn't advance: expected epoch %d, got %d", identifier, epochInfo.CurrentEpoch+1, newEpochInfo.CurrentEpoch)
+	}
+	return newEpochInfo.CurrentEpoch, nil
+}
+
+// nextEpochBoundary returns the block time of the next intermediate block required to reach
+// the given block time, which is needed when an epoch has more than one boundary pending
+// until then. It is the earliest first pending boundary of those epochs, so that the last
+// boundary of each epoch is left for the block at the given time. Epochs with a single
+// pending boundary, even if it is already due, don't require an intermediate block.
+// It returns false if no intermediate block is needed or if the epochs BeginBlock is disabled.
+func (n *IntegrationNetwork) nextEpochBoundary(blockTime time.Time) (time.Time, bool) {
+	for _, name := range n.cfg.disabledModuleHooks {
+		if name == epochstypes.ModuleName {
+			return time.Time{}, false
+		}
+	}
+
+	earliest := n.ctx.BlockTime().Add(time.Nanosecond)
+	var next time.Time
+	found := false
+	for _, epochInfo := range n.app.EpochsKeeper.AllEpochInfos(n.ctx) {
+		// NOTE: the epoch ends on the first block whose time is after the epoch end time
+		first := epochInfo.CurrentEpochStartTime.Add(epochInfo.Duration).Add(time.Nanosecond)
+		second := first.Add(epochInfo.Duration)
+		if !epochInfo.EpochCountingStarted {
+			first = epochInfo.StartTime
+			second = epochInfo.StartTime.Add(epochInfo.Duration).Add(time.Nanosecond)
+		}
+		if second.After(blockTime) {
+			continue
+		}
+
+		boundary := first
+		if boundary.Before(earliest) {
+			boundary = earliest
+		}
+		if !boundary.Before(blockTime) {
+			continue
+		}
+		if !found || boundary.Before(next) {
+			next, found = boundary, true
+		}
+	}
+	return next, found
+}
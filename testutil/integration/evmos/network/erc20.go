@@ -0,0 +1,247 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/evmos/evmos/v16/app"
+	"github.com/evmos/evmos/v16/contracts"
+	"github.com/evmos/evmos/v16/precompiles/erc20"
+	"github.com/evmos/evmos/v16/precompiles/werc20"
+	erc20types "github.com/evmos/evmos/v16/x/erc20/types"
+)
+
+// NativeErc20Pair defines a native coin that is registered at genesis as an
+// erc20 token pair, together with the ERC20 contract that represents it.
+type NativeErc20Pair struct {
+	Denom    string
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// nativeErc20Seeder deploys the ERC20 contracts of the native token pairs on an
+// in-memory EVM state from the erc20 module address, in the same way the erc20
+// module does when a coin is registered.
+type nativeErc20Seeder struct {
+	state *state.StateDB
+}
+
+// newNativeErc20Seeder creates a seeder with an empty EVM state. The state keeps
+// the preimages of the storage keys, so that the contracts storage can be exported.
+func newNativeErc20Seeder() (*nativeErc20Seeder, error) {
+	db := state.NewDatabaseWithConfig(rawdb.NewMemoryDatabase(), &trie.Config{Preimages: true})
+	stateDB, err := state.New(common.Hash{}, db, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &nativeErc20Seeder{state: stateDB}, nil
+}
+
+// nonce returns the nonce of the erc20 module address, which is the sequence
+// the erc20 module account must have at genesis.
+func (s *nativeErc20Seeder) nonce() uint64 {
+	return s.state.GetNonce(erc20types.ModuleAddress)
+}
+
+// seedNativeErc20Pair deploys the ERC20 contract for the given native coin and
+// returns the token pair, the contract to include in the EVM genesis and the
+// coin metadata to include in the bank genesis.
+func (s *nativeErc20Seeder) seedNativeErc20Pair(denom, name, symbol string, decimals uint8) (erc20types.TokenPair, GenesisContract, banktypes.Metadata, error) {
+	metadata := nativeCoinMetadata(denom, name, symbol, decimals)
+	if err := metadata.Validate(); err != nil {
+		return erc20types.TokenPair{}, GenesisContract{}, banktypes.Metadata{}, fmt.Errorf("invalid metadata for %s: %w", denom, err)
+	}
+
+	ctorArgs, err := contracts.ERC20MinterBurnerDecimalsContract.ABI.Pack("", name, symbol, decimals)
+	if err != nil {
+		return erc20types.TokenPair{}, GenesisContract{}, banktypes.Metadata{}, fmt.Errorf("failed to pack constructor arguments for %s: %w", denom, err)
+	}
+	data := append(append([]byte{}, contracts.ERC20MinterBurnerDecimalsContract.Bin...), ctorArgs...)
+
+	// NOTE: the contract is deployed from the module address, so its address is
+	// derived from the nonce of the module address like on the erc20 keeper.
+	code, contractAddr, _, err := runtime.Create(data, &runtime.Config{
+		Origin: erc20types.ModuleAddress,
+		State:  s.state,
+	})
+	if err != nil {
+		return erc20types.TokenPair{}, GenesisContract{}, banktypes.Metadata{}, fmt.Errorf("failed to deploy ERC20 contract for %s: %w", denom, err)
+	}
+	// Commit the state so that the contract storage can be iterated
+	if _, err := s.state.Commit(true); err != nil {
+		return erc20types.TokenPair{}, GenesisContract{}, banktypes.Metadata{}, err
+	}
+	storage := make(map[common.Hash]common.Hash)
+	err = s.state.ForEachStorage(contractAddr, func(key, value common.Hash) bool {
+		storage[key] = value
+		return true
+	})
+	if err != nil {
+		return erc20types.TokenPair{}, GenesisContract{}, banktypes.Metadata{}, fmt.Errorf("failed to export ERC20 contract storage for %s: %w", denom, err)
+	}
+
+	tokenPair := erc20types.NewTokenPair(contractAddr, denom, erc20types.OWNER_MODULE)
+	contract := GenesisContract{
+		Code:    code,
+		Storage: storage,
+		Nonce:   s.state.GetNonce(contractAddr),
+	}
+	return tokenPair, contract, metadata, nil
+}
+
+// nativeCoinMetadata returns the bank metadata of a native coin with the given
+// decimals. The display denomination is the lowercase symbol.
+func nativeCoinMetadata(denom, name, symbol string, decimals uint8) banktypes.Metadata {
+	metadata := banktypes.Metadata{
+		Description: fmt.Sprintf("Native coin %s registered as an ERC20 token pair", denom),
+		Base:        denom,
+		DenomUnits: []*banktypes.DenomUnit{
+			{
+				Denom:    denom,
+				Exponent: 0,
+			},
+		},
+		Name:    name,
+		Symbol:  symbol,
+		Display: denom,
+	}
+	if decimals > 0 {
+		display := strings.ToLower(symbol)
+		metadata.DenomUnits = append(metadata.DenomUnits, &banktypes.DenomUnit{
+			Denom:    display,
+			Exponent: uint32(decimals),
+		})
+		metadata.Display = display
+	}
+	return metadata
+}
+
+// Erc20NativePairsGenesis holds the genesis entries of the native token pairs
+// for the erc20, EVM, bank and auth modules.
+type Erc20NativePairsGenesis struct {
+	tokenPairs    []erc20types.TokenPair
+	contracts     map[common.Address]GenesisContract
+	metadata      []banktypes.Metadata
+	moduleAccount authtypes.GenesisAccount
+}
+
+// createNativeErc20Pairs seeds the given native token pairs. The erc20 module
+// account is created with the sequence that follows the contract deployments,
+// so that the coins registered afterwards get a different contract address.
+func createNativeErc20Pairs(pairs []NativeErc20Pair) (Erc20NativePairsGenesis, error) {
+	seeded := Erc20NativePairsGenesis{
+		tokenPairs: make([]erc20types.TokenPair, 0, len(pairs)),
+		contracts:  make(map[common.Address]GenesisContract, len(pairs)),
+		metadata:   make([]banktypes.Metadata, 0, len(pairs)),
+	}
+	if len(pairs) == 0 {
+		return seeded, nil
+	}
+
+	seeder, err := newNativeErc20Seeder()
+	if err != nil {
+		return Erc20NativePairsGenesis{}, err
+	}
+
+	denoms := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		if denoms[pair.Denom] {
+			return Erc20NativePairsGenesis{}, fmt.Errorf("duplicate native token pair denom %s", pair.Denom)
+		}
+		denoms[pair.Denom] = true
+
+		tokenPair, contract, metadata, err := seeder.seedNativeErc20Pair(pair.Denom, pair.Name, pair.Symbol, pair.Decimals)
+		if err != nil {
+			return Erc20NativePairsGenesis{}, err
+		}
+		seeded.tokenPairs = append(seeded.tokenPairs, tokenPair)
+		seeded.contracts[tokenPair.GetERC20Contract()] = contract
+		seeded.metadata = append(seeded.metadata, metadata)
+	}
+
+	moduleAccount := authtypes.NewEmptyModuleAccount(erc20types.ModuleName, authtypes.Minter, authtypes.Burner)
+	if err := moduleAccount.SetSequence(seeder.nonce()); err != nil {
+		return Erc20NativePairsGenesis{}, err
+	}
+	seeded.moduleAccount = moduleAccount
+	return seeded, nil
+}
+
+// mergeGenesisContracts returns the union of the given genesis contracts.
+// It returns an error if a contract address is included in both.
+func mergeGenesisContracts(contracts, other map[common.Address]GenesisContract) (map[common.Address]GenesisContract, error) {
+	merged := make(map[common.Address]GenesisContract, len(contracts)+len(other))
+	for addr, contract := range contracts {
+		merged[addr] = contract
+	}
+	for addr, contract := range other {
+		if _, found := merged[addr]; found {
+			return nil, fmt.Errorf("duplicate genesis contract address %s", addr)
+		}
+		merged[addr] = contract
+	}
+	return merged, nil
+}
+
+// Erc20ContractAddress returns the address of the ERC20 contract that the erc20 module
+// deployed for the given native coin denom, e.g. one of the native token pairs seeded
+// at genesis. It returns false if the denom isn't registered as a native coin pair.
+func (n *IntegrationNetwork) Erc20ContractAddress(denom string) (common.Address, bool) {
+	id := n.app.Erc20Keeper.GetDenomMap(n.ctx, denom)
+	tokenPair, found := n.app.Erc20Keeper.GetTokenPair(n.ctx, id)
+	if !found || !tokenPair.IsNativeCoin() {
+		return common.Address{}, false
+	}
+	return tokenPair.GetERC20Contract(), true
+}
+
+// createErc20Precompiles creates the ERC20 precompiles for the native coin token pairs with
+// the given contract addresses, in the same way as the erc20 module registers them. The
+// token pair of the EVM denom uses the WERC20 precompile.
+// It returns an error if any of the addresses is not a valid hex address or is not the
+// contract of a registered native coin token pair.
+func createErc20Precompiles(ctx sdktypes.Context, evmosApp *app.Evmos, addrs []string) (map[common.Address]vm.PrecompiledContract, error) {
+	evmDenom := evmosApp.EvmKeeper.GetParams(ctx).EvmDenom
+	precompiles := make(map[common.Address]vm.PrecompiledContract, len(addrs))
+	for _, hexAddr := range addrs {
+		if !common.IsHexAddress(hexAddr) {
+			return nil, fmt.Errorf("invalid ERC20 precompile address %s", hexAddr)
+		}
+		addr := common.HexToAddress(hexAddr)
+		if _, found := precompiles[addr]; found {
+			return nil, fmt.Errorf("duplicate ERC20 precompile address %s", addr)
+		}
+
+		tokenPair, found := evmosApp.Erc20Keeper.GetTokenPair(ctx, evmosApp.Erc20Keeper.GetERC20Map(ctx, addr))
+		if !found || !tokenPair.IsNativeCoin() {
+			return nil, fmt.Errorf("ERC20 precompile address %s is not the contract of a registered native coin token pair", addr)
+		}
+
+		var (
+			precompile vm.PrecompiledContract
+			err        error
+		)
+		if tokenPair.Denom == evmDenom {
+			precompile, err = werc20.NewPrecompile(tokenPair, evmosApp.BankKeeper, evmosApp.AuthzKeeper, evmosApp.TransferKeeper)
+		} else {
+			precompile, err = erc20.NewPrecompile(tokenPair, evmosApp.BankKeeper, evmosApp.AuthzKeeper, evmosApp.TransferKeeper)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ERC20 precompile for %s: %w", tokenPair.Denom, err)
+		}
+		precompiles[addr] = precompile
+	}
+	return precompiles, nil
+}
@@ -0,0 +1,71 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+)
+
+// retainedEventBlocks is the amount of the latest blocks whose events are retained by
+// the network to be queried with EventsByType.
+const retainedEventBlocks = 100
+
+// EventsByType returns the events of the given type emitted on the block with the given
+// height, in order of emission. They include the events of the BeginBlock, the delivered
+// transactions and the EndBlock of the block.
+// It returns an error if the events of the height are not retained, i.e. if the block
+// is not one of the latest blocks since the network was created or its state loaded.
+func (n *IntegrationNetwork) EventsByType(height int64, eventType string) ([]abcitypes.Event, error) {
+	blockEvents, found := n.blockEvents[height]
+	if !found {
+		return nil, fmt.Errorf("events of block %d are not retained", height)
+	}
+
+	events := []abcitypes.Event{}
+	for _, event := range blockEvents {
+		if event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// recordBlockEvents appends the given events to the ones emitted on the block with the
+// given height and prunes the events of the blocks that are no longer retained.
+func (n *IntegrationNetwork) recordBlockEvents(height int64, events []abcitypes.Event) {
+	if n.blockEvents == nil {
+		n.blockEvents = make(map[int64][]abcitypes.Event)
+	}
+	n.blockEvents[height] = append(n.blockEvents[height], events...)
+
+	for retainedHeight := range n.blockEvents {
+		if retainedHeight <= height-retainedEventBlocks {
+			delete(n.blockEvents, retainedHeight)
+		}
+	}
+}
+
+// TxResultHook defines a function that receives the result of a delivered transaction
+// once its block is committed, together with the height of the block, the index of the
+// transaction in the block and the raw transaction bytes.
+type TxResultHook func(height int64, txIndex int, txBytes []byte, res abcitypes.ResponseDeliverTx)
+
+// blockTx holds a transaction delivered on the current block and its result
+type blockTx struct {
+	txBytes []byte
+	res     abcitypes.ResponseDeliverTx
+}
+
+// runTxResultHooks calls the configured tx result hooks with the transactions delivered
+// on the block with the given height, in the order they were delivered, and clears them.
+func (n *IntegrationNetwork) runTxResultHooks(height int64) {
+	blockTxs := n.blockTxs
+	n.blockTxs = nil
+	for _, hook := range n.cfg.txResultHooks {
+		for i, tx := range blockTxs {
+			hook(height, i, tx.txBytes, tx.res)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/evmos/evmos/v16/x/evm/statedb"
+)
+
+// GetStorageAt returns the value of the given storage slot of the given contract
+// on the latest committed state. The zero hash is returned for unset slots.
+func (n *IntegrationNetwork) GetStorageAt(addr common.Address, slot common.Hash) (common.Hash, error) {
+	ctx, err := n.latestCommittedContext()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return n.app.EvmKeeper.GetState(ctx, addr, slot), nil
+}
+
+// GetCode returns the code of the given contract on the latest committed state.
+// It returns nil if the address is not a contract.
+func (n *IntegrationNetwork) GetCode(addr common.Address) ([]byte, error) {
+	ctx, err := n.latestCommittedContext()
+	if err != nil {
+		return nil, err
+	}
+	account := n.app.EvmKeeper.GetAccountWithoutBalance(ctx, addr)
+	if account == nil || !account.IsContract() {
+		return nil, nil
+	}
+	return n.app.EvmKeeper.GetCode(ctx, common.BytesToHash(account.CodeHash)), nil
+}
+
+// GetEVMAccount returns the balance, nonce and code hash of the given address on the
+// latest committed state, in the same way as the EVM reads them. An empty account,
+// i.e. with zero balance and nonce and the empty code hash, is returned for the
+// addresses that don't exist.
+func (n *IntegrationNetwork) GetEVMAccount(addr common.Address) (statedb.Account, error) {
+	ctx, err := n.latestCommittedContext()
+	if err != nil {
+		return statedb.Account{}, err
+	}
+	account := n.app.EvmKeeper.GetAccount(ctx, addr)
+	if account == nil {
+		return *statedb.NewEmptyAccount(), nil
+	}
+	return *account, nil
+}
+
+// latestCommittedContext returns a query context on the state of the last committed block.
+func (n *IntegrationNetwork) latestCommittedContext() (sdktypes.Context, error) {
+	ctx, err := n.GetContextAtHeight(n.app.LastBlockHeight())
+	if err != nil {
+		return sdktypes.Context{}, fmt.Errorf("failed to create context at the latest committed height: %w", err)
+	}
+	return ctx, nil
+}
@@ -0,0 +1,39 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"cosmossdk.io/simapp"
+)
+
+// ExportGenesis commits the current block and exports the genesis state of all the
+// modules. The genesis is exported for zero height, so that the validators' heights
+// are reset and the genesis can be imported into a new network.
+//
+// NOTE: the export runs on an app loaded from a copy of the network database, because
+// preparing the zero height genesis modifies the state, e.g. withdrawing all rewards.
+func (n *IntegrationNetwork) ExportGenesis() (simapp.GenesisState, error) {
+	if err := n.NextBlock(); err != nil {
+		return nil, fmt.Errorf("failed to commit block before export: %w", err)
+	}
+
+	db, err := copyMemDB(n.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy network database: %w", err)
+	}
+	evmosApp := createEvmosApp(n.cfg.chainID, n.cfg.homePath, n.cfg.blockedAddresses, db, n.cfg.logger, nil)
+
+	exported, err := evmosApp.ExportAppStateAndValidators(true, []string{}, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export app state: %w", err)
+	}
+
+	var genesisState simapp.GenesisState
+	if err := json.Unmarshal(exported.AppState, &genesisState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exported app state: %w", err)
+	}
+	return genesisState, nil
+}
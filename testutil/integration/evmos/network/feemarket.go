@@ -0,0 +1,24 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	sdkmath "cosmossdk.io/math"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+)
+
+// NextBaseFee returns the base fee that applies to the next block, given the gas wanted
+// on the current block. It runs the fee market EndBlock and the base fee calculation of
+// the next BeginBlock on a discarded cache context, so the network state is not modified.
+// It returns zero if the base fee is disabled.
+func (n *IntegrationNetwork) NextBaseFee() sdkmath.Int {
+	ctx, _ := n.ctx.CacheContext()
+	n.app.FeeMarketKeeper.EndBlock(ctx, abcitypes.RequestEndBlock{Height: ctx.BlockHeight()})
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	baseFee := n.app.FeeMarketKeeper.CalculateBaseFee(ctx)
+	if baseFee == nil {
+		return sdkmath.ZeroInt()
+	}
+	return sdkmath.NewIntFromBigInt(baseFee)
+}
@@ -4,15 +4,28 @@
 package network
 
 import (
+	"fmt"
+
 	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	inflationtypes "github.com/evmos/evmos/v16/x/inflation/v1/types"
 )
 
 // FundAccount funds the given account with the given amount of coins.
+// The coins are minted, so the total supply is updated accordingly.
+// It returns an error if the coins are invalid or if transfers are
+// disabled for any of their denominations.
 func (n *IntegrationNetwork) FundAccount(addr sdk.AccAddress, coins sdk.Coins) error {
 	ctx := n.GetContext()
 
+	if err := coins.Validate(); err != nil {
+		return fmt.Errorf("invalid coins %s: %w", coins, err)
+	}
+
+	if err := n.app.BankKeeper.IsSendEnabledCoins(ctx, coins...); err != nil {
+		return fmt.Errorf("failed to fund account %s: %w", addr, err)
+	}
+
 	if err := n.app.BankKeeper.MintCoins(ctx, inflationtypes.ModuleName, coins); err != nil {
 		return err
 	}
@@ -0,0 +1,54 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MeasureGas returns the gas consumed by the given action. The action runs first on a
+// context with an infinite gas meter and then on a context metered with the gas consumed
+// on the first run, so that the measurement is checked to be deterministic.
+//
+// NOTE: both runs use a cached branch of the current state that is discarded, so the
+// state changes of the action are not persisted.
+//
+// It returns an error if the action fails or if the gas consumed differs between the runs.
+func (n *IntegrationNetwork) MeasureGas(action func(ctx sdktypes.Context) error) (uint64, error) {
+	infiniteCtx, _ := n.ctx.CacheContext()
+	infiniteCtx = infiniteCtx.WithGasMeter(sdktypes.NewInfiniteGasMeter())
+	if err := action(infiniteCtx); err != nil {
+		return 0, fmt.Errorf("action failed on infinite gas context: %w", err)
+	}
+	expGas := infiniteCtx.GasMeter().GasConsumed()
+
+	meteredCtx, _ := n.ctx.CacheContext()
+	meteredCtx = meteredCtx.WithGasMeter(sdktypes.NewGasMeter(expGas))
+	if err := runMetered(meteredCtx, action); err != nil {
+		return 0, fmt.Errorf("action failed on metered context: %w", err)
+	}
+
+	gas := meteredCtx.GasMeter().GasConsumed()
+	if gas != expGas {
+		return 0, fmt.Errorf("non-deterministic gas consumption: %d on infinite gas context, %d on metered context", expGas, gas)
+	}
+	return gas, nil
+}
+
+// runMetered runs the given action on the given context and returns an error if the
+// action runs out of gas, instead of panicking.
+func runMetered(ctx sdktypes.Context, action func(ctx sdktypes.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			outOfGas, ok := r.(storetypes.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("out of gas in location %s", outOfGas.Descriptor)
+		}
+	}()
+	return action(ctx)
+}
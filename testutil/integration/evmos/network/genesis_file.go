@@ -0,0 +1,234 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"cosmossdk.io/simapp"
+	dbm "github.com/cometbft/cometbft-db"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/evmos/evmos/v16/app"
+	"github.com/evmos/evmos/v16/encoding"
+	evmostypes "github.com/evmos/evmos/v16/types"
+	feemarkettypes "github.com/evmos/evmos/v16/x/feemarket/types"
+)
+
+// NewNetworkFromGenesisFile configures and initializes a new integration Network
+// instance from the exported genesis file at the given path. The custom genesis
+// states of the configuration overwrite the ones of the file for their modules,
+// and the genesis mutators run on the resulting genesis state.
+// The configured chain ID is used even if it differs from the one in the file.
+//
+// The options that build the genesis state from the configuration, like the
+// validator or vesting account options, conflict with the genesis file and
+// are rejected. The prefunded accounts are ignored.
+//
+// NOTE: the private keys of the genesis validators are not available, so the
+// network cannot be used as an IBC test chain.
+//
+// It panics if an error occurs.
+func NewNetworkFromGenesisFile(path string, opts ...ConfigOption) *IntegrationNetwork {
+	cfg := DefaultConfig()
+	// Modify the default config with the given options
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	network := &IntegrationNetwork{
+		cfg:        cfg,
+		ctx:        sdktypes.Context{},
+		validators: []stakingtypes.Validator{},
+	}
+
+	err := network.configureAndInitChainFromGenesisFile(path)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// validateGenesisFileConfig returns an error if any of the options that build the
+// genesis state from the configuration is set, since the genesis state is read
+// from the genesis file instead.
+func validateGenesisFileConfig(cfg Config) error {
+	defaultCfg := DefaultConfig()
+	unsupported := []struct {
+		option string
+		set    bool
+	}{
+		{"WithAmountOfValidators", cfg.amountOfValidators != defaultCfg.amountOfValidators},
+		{"WithNoValidators", cfg.noValidators},
+		{"WithValidatorPowers", cfg.validatorPowers != nil},
+		{"WithValidatorCommissions", cfg.validatorCommissions != nil},
+		{"WithJailedValidators", cfg.jailedValidators != nil},
+		{"WithValidatorPrivVals", cfg.validatorPrivVals != nil},
+		{"WithPreFundedAccountsMeta", cfg.accountSequences != nil || cfg.accountNumbers != nil},
+		{"WithPreFundedCoins", cfg.preFundedCoins != nil},
+		{"WithEVMContracts", cfg.evmContracts != nil},
+		{"WithVestingAccounts", cfg.vestingAccounts != nil},
+		{"WithGenesisTime", !cfg.genesisTime.IsZero()},
+		{"WithDelegations", cfg.delegations != nil},
+		{"WithUnbondingDelegations", cfg.unbondingDelegations != nil},
+		{"WithRedelegations", cfg.redelegations != nil},
+		{"WithUnbondingTime", cfg.unbondingTime != 0},
+		{"WithMaxValidators", cfg.maxValidators != 0},
+		{"WithMaxEntries", cfg.maxEntries != 0},
+		{"WithHistoricalEntries", cfg.historicalEntries != nil},
+		{"WithEVMDisabled", cfg.evmDisabled},
+		{"WithInflationEnabled", cfg.inflationEnabled},
+		{"WithEpochs", cfg.epochs != nil},
+		{"WithNativeErc20Pairs", cfg.nativeErc20Pairs != nil},
+		{"WithDenomMetadata", cfg.denomMetadata != nil},
+		{"WithSendEnabled", cfg.sendEnabled != nil},
+		{"WithBlockGasWanted", cfg.blockGasWanted != 0},
+		{"WithCommunityPool", cfg.communityPool != nil},
+		{"WithFeeCollectorBalance", cfg.feeCollectorBalance != nil},
+		{"WithGovParams", cfg.govParams != nil},
+		{"WithEVMParams", cfg.evmParamsModifiers != nil},
+		{"WithSlashingWindow", cfg.signedBlocksWindow != 0 || cfg.minSignedPerWindow != nil},
+		{"WithWithdrawAddress", cfg.withdrawAddresses != nil},
+		{"WithInitialHeight", cfg.initialHeight != defaultCfg.initialHeight},
+		{"WithModuleAccount", cfg.moduleAccounts != nil},
+		{"WithDepositPeriodProposal", cfg.depositPeriodProposals != nil},
+	}
+	for _, opt := range unsupported {
+		if opt.set {
+			return fmt.Errorf("%s is not supported when the network is created from a genesis file, use a custom genesis state instead", opt.option)
+		}
+	}
+	return nil
+}
+
+// configureAndInitChainFromGenesisFile initializes the network with the genesis
+// state of the given genesis file and starts the network.
+func (n *IntegrationNetwork) configureAndInitChainFromGenesisFile(path string) error {
+	if err := validateGenesisFileConfig(n.cfg); err != nil {
+		return err
+	}
+
+	eip155ChainID, err := evmostypes.ParseChainID(n.cfg.chainID)
+	if err != nil {
+		return fmt.Errorf("invalid chain ID %q, expected the format {identifier}_{EIP155 number}-{version}: %w", n.cfg.chainID, err)
+	}
+	n.cfg.eip155ChainID = eip155ChainID
+
+	genDoc, err := tmtypes.GenesisDocFromFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read genesis file: %w", err)
+	}
+	genDoc.ChainID = n.cfg.chainID
+	n.cfg.genesisTime = genDoc.GenesisTime
+	n.cfg.initialHeight = genDoc.InitialHeight
+
+	var genesisState simapp.GenesisState
+	if err := json.Unmarshal(genDoc.AppState, &genesisState); err != nil {
+		return fmt.Errorf("failed to unmarshal genesis app state: %w", err)
+	}
+
+	// Overwrite the genesis state of the file with the custom genesis states
+	cdc := encoding.MakeConfig(app.ModuleBasics).Codec
+	for moduleName, customGenesis := range n.cfg.customGenesisState {
+		moduleGenesis, ok := customGenesis.(proto.Message)
+		if !ok {
+			return fmt.Errorf("invalid type %T for %s module genesis state", customGenesis, moduleName)
+		}
+		genesisState[moduleName], err = cdc.MarshalJSON(moduleGenesis)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, mutator := range n.cfg.genesisMutators {
+		genesisState = mutator(genesisState)
+		if genesisState == nil {
+			return fmt.Errorf("genesis mutator %d returned a nil genesis state", i)
+		}
+	}
+
+	feeMarketGenesis := feemarkettypes.DefaultGenesisState()
+	if feeMarketGenesisBz, ok := genesisState[feemarkettypes.ModuleName]; ok {
+		if err := cdc.UnmarshalJSON(feeMarketGenesisBz, feeMarketGenesis); err != nil {
+			return fmt.Errorf("failed to unmarshal fee market genesis state: %w", err)
+		}
+	}
+	if err := n.setMinGasPrices(feeMarketGenesis); err != nil {
+		return err
+	}
+
+	if err := n.createHomePath(); err != nil {
+		return err
+	}
+
+	db := dbm.NewMemDB()
+	evmosApp := createEvmosApp(n.cfg.chainID, n.cfg.homePath, n.cfg.blockedAddresses, db, n.cfg.logger, n.cfg.anteHandler, baseapp.SetMinGasPrices(n.minGasPrices.String()))
+	if err := disableModuleHooks(evmosApp, n.cfg.disabledModuleHooks); err != nil {
+		return err
+	}
+
+	stateBytes, err := json.MarshalIndent(genesisState, "", " ")
+	if err != nil {
+		return err
+	}
+
+	consensusParams := app.DefaultConsensusParams
+	if genDoc.ConsensusParams != nil {
+		params := genDoc.ConsensusParams.ToProto()
+		consensusParams = &params
+	}
+
+	res := evmosApp.InitChain(
+		abcitypes.RequestInitChain{
+			Time:            genDoc.GenesisTime,
+			ChainId:         genDoc.ChainID,
+			InitialHeight:   genDoc.InitialHeight,
+			Validators:      []abcitypes.ValidatorUpdate{},
+			ConsensusParams: consensusParams,
+			AppStateBytes:   stateBytes,
+		},
+	)
+	// Commit genesis changes
+	evmosApp.Commit()
+
+	tmValidators, err := tmtypes.PB2TM.ValidatorUpdates(res.Validators)
+	if err != nil {
+		return err
+	}
+	if len(tmValidators) == 0 {
+		return fmt.Errorf("genesis file %s has no bonded validators", path)
+	}
+	valSet := tmtypes.NewValidatorSet(tmValidators)
+
+	header := tmproto.Header{
+		ChainID:            n.cfg.chainID,
+		Height:             evmosApp.LastBlockHeight() + 1,
+		Time:               genDoc.GenesisTime,
+		AppHash:            evmosApp.LastCommitID().Hash,
+		ValidatorsHash:     valSet.Hash(),
+		NextValidatorsHash: valSet.Hash(),
+		ProposerAddress:    valSet.Proposer.Address,
+	}
+	beginBlockRes := evmosApp.BeginBlock(abcitypes.RequestBeginBlock{Header: header})
+	n.recordBlockEvents(header.Height, beginBlockRes.Events)
+
+	// Set networks global parameters
+	n.app = evmosApp
+	n.db = db
+	n.ctx = evmosApp.BaseApp.NewContext(false, header)
+	n.ctx = n.ctx.WithConsensusParams(consensusParams)
+	n.ctx = n.ctx.WithBlockGasMeter(sdktypes.NewInfiniteGasMeter())
+
+	n.validators = evmosApp.StakingKeeper.GetAllValidators(n.ctx)
+	n.setValidatorSet(valSet)
+	n.valSigners = map[string]tmtypes.PrivValidator{}
+	n.genesisTotalSupply = n.TotalSupply()
+
+	return n.setupInitializedChain(header)
+}
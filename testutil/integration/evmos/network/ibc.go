@@ -11,7 +11,11 @@ import (
 // GetIBCChain returns a TestChain instance for the given network.
 // Note: the sender accounts are not populated. Do not use this accounts to send transactions during tests.
 // The keyring should be used instead.
+//
+// NOTE: the test chain gets a copy of the validator set, because both the chain and the
+// network update the proposer priorities of their validator set on each block.
 func (n *IntegrationNetwork) GetIBCChain(t *testing.T, coord *ibctesting.Coordinator) *ibctesting.TestChain {
+	valSet := n.valSet.Copy()
 	return &ibctesting.TestChain{
 		T:             t,
 		Coordinator:   coord,
@@ -21,8 +25,8 @@ func (n *IntegrationNetwork) GetIBCChain(t *testing.T, coord *ibctesting.Coordin
 		QueryServer:   n.app.GetIBCKeeper(),
 		TxConfig:      n.app.GetTxConfig(),
 		Codec:         n.app.AppCodec(),
-		Vals:          n.valSet,
-		NextVals:      n.valSet,
+		Vals:          valSet,
+		NextVals:      valSet,
 		Signers:       n.valSigners,
 	}
 }
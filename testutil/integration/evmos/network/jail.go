@@ -0,0 +1,113 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+	"time"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// JailValidator jails the validator with the given operator address and commits the
+// block. The validator update returned by the EndBlocker removes the validator from the
+// active validator set, so it doesn't sign or propose the blocks after the next one, as
+// CometBFT applies the validator updates with a delay of one block. It can be unjailed
+// with UnjailValidator once the downtime jail duration of the slashing params has elapsed.
+// It returns an error if the validator is not found, if it is already jailed or if it
+// is the last validator of the active validator set.
+func (n *IntegrationNetwork) JailValidator(valAddr string) error {
+	validator, err := n.getStakingValidator(valAddr)
+	if err != nil {
+		return err
+	}
+	if validator.IsJailed() {
+		return fmt.Errorf("validator %s is already jailed", valAddr)
+	}
+
+	consAddr, err := validator.GetConsAddr()
+	if err != nil {
+		return err
+	}
+	if n.nextValSet.HasAddress(consAddr) && n.nextValSet.Size() == 1 {
+		return fmt.Errorf("cannot jail validator %s: at least one validator must be active to produce blocks", valAddr)
+	}
+
+	n.app.SlashingKeeper.Jail(n.ctx, consAddr)
+
+	// The genesis validators have no signing info, which holds the end of the jail period
+	signingInfo, found := n.app.SlashingKeeper.GetValidatorSigningInfo(n.ctx, consAddr)
+	if !found {
+		signingInfo = slashingtypes.NewValidatorSigningInfo(consAddr, n.ctx.BlockHeight(), 0, time.Unix(0, 0), false, 0)
+	}
+	signingInfo.JailedUntil = n.ctx.BlockTime().Add(n.app.SlashingKeeper.DowntimeJailDuration(n.ctx))
+	n.app.SlashingKeeper.SetValidatorSigningInfo(n.ctx, consAddr, signingInfo)
+
+	return n.NextBlock()
+}
+
+// UnjailValidator unjails the validator with the given operator address and commits the
+// block. The validator update returned by the EndBlocker adds the validator back to the
+// active validator set, so it signs and can propose the blocks after the next one.
+//
+// NOTE: the network validators have no self-delegation, so the checks of the slashing
+// unjail message are done here, except for the min self-delegation.
+//
+// It returns an error if the validator is not found, if it is not jailed, if it is
+// tombstoned or if the jail period has not elapsed.
+func (n *IntegrationNetwork) UnjailValidator(valAddr string) error {
+	validator, err := n.getStakingValidator(valAddr)
+	if err != nil {
+		return err
+	}
+	if !validator.IsJailed() {
+		return fmt.Errorf("%w: %s", slashingtypes.ErrValidatorNotJailed, valAddr)
+	}
+
+	consAddr, err := validator.GetConsAddr()
+	if err != nil {
+		return err
+	}
+
+	signingInfo, found := n.app.SlashingKeeper.GetValidatorSigningInfo(n.ctx, consAddr)
+	if found {
+		if signingInfo.Tombstoned {
+			return fmt.Errorf("%w: validator %s is tombstoned", slashingtypes.ErrValidatorJailed, valAddr)
+		}
+		if n.ctx.BlockTime().Before(signingInfo.JailedUntil) {
+			return fmt.Errorf("%w: validator %s is jailed until %s", slashingtypes.ErrValidatorJailed, valAddr, signingInfo.JailedUntil)
+		}
+	}
+
+	n.app.StakingKeeper.Unjail(n.ctx, consAddr)
+	return n.NextBlock()
+}
+
+// getStakingValidator returns the staking validator with the given operator address
+// on the current state.
+func (n *IntegrationNetwork) getStakingValidator(valAddr string) (stakingtypes.Validator, error) {
+	operator, err := sdktypes.ValAddressFromBech32(valAddr)
+	if err != nil {
+		return stakingtypes.Validator{}, fmt.Errorf("invalid validator address %s: %w", valAddr, err)
+	}
+	validator, found := n.app.StakingKeeper.GetValidator(n.ctx, operator)
+	if !found {
+		return stakingtypes.Validator{}, fmt.Errorf("validator %s not found", valAddr)
+	}
+	return validator, nil
+}
+
+// GetSigningInfo returns the signing info of the validator with the given consensus
+// address on the current state, e.g. to check the missed blocks counter and the end
+// of the jail period on downtime tests.
+// It returns an error if the validator has no signing info. The genesis validators
+// only have signing info once SignBlockWith is called or once they are jailed.
+func (n *IntegrationNetwork) GetSigningInfo(valConsAddr sdktypes.ConsAddress) (slashingtypes.ValidatorSigningInfo, error) {
+	signingInfo, found := n.app.SlashingKeeper.GetValidatorSigningInfo(n.ctx, valConsAddr)
+	if !found {
+		return slashingtypes.ValidatorSigningInfo{}, fmt.Errorf("signing info of validator %s not found", valConsAddr)
+	}
+	return signingInfo, nil
+}
@@ -5,23 +5,38 @@ package network
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"math/big"
+	"os"
+	"time"
 
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 
 	"github.com/evmos/evmos/v16/app"
-	"github.com/evmos/evmos/v16/types"
+	evmostypes "github.com/evmos/evmos/v16/types"
 
+	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/simapp"
+	"cosmossdk.io/simapp/params"
+	dbm "github.com/cometbft/cometbft-db"
 	abcitypes "github.com/cometbft/cometbft/abci/types"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
-	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1types "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ibcexported "github.com/cosmos/ibc-go/v7/modules/core/exported"
+	"github.com/ethereum/go-ethereum/common"
 	commonnetwork "github.com/evmos/evmos/v16/testutil/integration/common/network"
 	erc20types "github.com/evmos/evmos/v16/x/erc20/types"
+	"github.com/evmos/evmos/v16/x/evm/statedb"
 	evmtypes "github.com/evmos/evmos/v16/x/evm/types"
 	feemarkettypes "github.com/evmos/evmos/v16/x/feemarket/types"
 	infltypes "github.com/evmos/evmos/v16/x/inflation/v1/types"
@@ -36,11 +51,102 @@ type Network interface {
 	commonnetwork.Network
 
 	GetEIP155ChainID() *big.Int
+	GetGenesisTime() time.Time
+	BlockTime() time.Time
+	GetContextAtHeight(height int64) (sdktypes.Context, error)
+	// AppHash returns the app hash of the latest committed block
+	AppHash() []byte
+	GetConsensusParams() *tmproto.ConsensusParams
+	EncodingConfig() params.EncodingConfig
+
+	// Supply
+	GetGenesisTotalSupply() sdktypes.Coins
+	TotalSupply() sdktypes.Coins
+	AssertTotalSupply(expected sdktypes.Coins) error
+	// IsBlockedAddress returns true if the address cannot receive funds
+	IsBlockedAddress(addr sdktypes.AccAddress) bool
+	MintCoins(moduleName string, coins sdktypes.Coins) error
+	BurnCoins(moduleName string, coins sdktypes.Coins) error
+
+	// GetAllBalances returns the balances of the address on the current state
+	GetAllBalances(addr sdktypes.AccAddress) sdktypes.Coins
+	// SpendableBalance returns the balance of the address that is not locked by a vesting schedule
+	SpendableBalance(addr sdktypes.AccAddress, denom string) sdktypes.Coin
+	// IterateAccounts iterates over the accounts on the current state until the callback returns true
+	IterateAccounts(cb func(account authtypes.AccountI) (stop bool))
+
+	// SignBlockWith sets the validators that don't sign the following blocks
+	SignBlockWith(absent []string) error
+	// SetNextProposer sets the validator that proposes the next block
+	SetNextProposer(valAddr string) error
+	// JailValidator and UnjailValidator change the jail status of a validator and commit the block
+	JailValidator(valAddr string) error
+	UnjailValidator(valAddr string) error
+	// GetSigningInfo returns the signing info of the validator with the given consensus address
+	GetSigningInfo(valConsAddr sdktypes.ConsAddress) (slashingtypes.ValidatorSigningInfo, error)
+	// DelegationRewards and ValidatorCommission return the distribution rewards that were not withdrawn
+	DelegationRewards(delegator, validator string) (sdktypes.DecCoins, error)
+	ValidatorCommission(valAddr string) (sdktypes.DecCoins, error)
+
+	// Module accounts
+	ModuleAddress(name string) (sdktypes.AccAddress, error)
+	FeeCollectorAddress() sdktypes.AccAddress
+	BondedPoolAddress() sdktypes.AccAddress
+	NotBondedPoolAddress() sdktypes.AccAddress
+	DistributionAddress() sdktypes.AccAddress
+
+	// MeasureGas returns the gas consumed by the given action without persisting its changes
+	MeasureGas(action func(ctx sdktypes.Context) error) (uint64, error)
+
+	// NextBaseFee returns the base fee of the next block given the current block gas wanted
+	NextBaseFee() sdkmath.Int
+
+	// SimulateTx runs the tx in simulation mode without committing any state change
+	SimulateTx(txBytes []byte) (sdktypes.GasInfo, *sdktypes.Result, error)
+
+	// CheckTx and ReCheckTx run the mempool checks without including the tx in a block
+	CheckTx(txBytes []byte) (abcitypes.ResponseCheckTx, error)
+	ReCheckTx(txBytes []byte) (abcitypes.ResponseCheckTx, error)
+	// EventsByType returns the events of the given type emitted on the block with the given height
+	EventsByType(height int64, eventType string) ([]abcitypes.Event, error)
+
+	// GetStorageAt and GetCode read the EVM state of the latest committed block
+	GetStorageAt(addr common.Address, slot common.Hash) (common.Hash, error)
+	GetCode(addr common.Address) ([]byte, error)
+	// GetEVMAccount returns the balance, nonce and code hash of the address on the latest committed state
+	GetEVMAccount(addr common.Address) (statedb.Account, error)
+	// ExpectNoStateChange checks that the given action leaves the EVM storage, balances and nonces unchanged
+	ExpectNoStateChange(action func() error, opts ...StateChangeOption) error
+	// Erc20ContractAddress returns the ERC20 contract address of a registered native coin denom
+	Erc20ContractAddress(denom string) (common.Address, bool)
+
+	// ExpectBalanceChange checks the balance change of an address caused by the given action
+	ExpectBalanceChange(addr sdktypes.AccAddress, denom string, delta sdkmath.Int, action func() error, opts ...BalanceChangeOption) error
+
+	// ReplayTxs delivers the signed transactions of the given file in order
+	ReplayTxs(path string, opts ...ReplayOption) ([]abcitypes.ResponseDeliverTx, error)
+
+	// CommitBlocks advances the network the given amount of blocks
+	CommitBlocks(amount int) error
+	// WaitNBlocks advances the network the given amount of blocks and returns their events
+	WaitNBlocks(amount int) ([]abcitypes.Event, error)
+
+	// AdvanceToNextEpoch begins a new block right after the end of the given epoch
+	AdvanceToNextEpoch(identifier string) (int64, error)
+
+	// ExportGenesis exports the modules genesis state for zero height
+	ExportGenesis() (simapp.GenesisState, error)
+
+	// Snapshot captures the network state to be restored later with RestoreSnapshot
+	Snapshot() (SnapshotID, error)
+	RestoreSnapshot(id SnapshotID) error
 
 	// Clients
+	GRPCClient() (QueryClients, error)
+	GRPCClientAtHeight(height int64) (QueryClients, error)
 	GetERC20Client() erc20types.QueryClient
 	GetEvmClient() evmtypes.QueryClient
-	GetGovClient() govtypes.QueryClient
+	GetGovClient() govv1types.QueryClient
 	GetRevenueClient() revtypes.QueryClient
 	GetInflationClient() infltypes.QueryClient
 	GetFeeMarketClient() feemarkettypes.QueryClient
@@ -48,9 +154,12 @@ type Network interface {
 	// Because to update the module params on a conventional manner governance
 	// would be required, we should provide an easier way to update the params
 	UpdateEvmParams(params evmtypes.Params) error
-	UpdateGovParams(params govtypes.Params) error
+	UpdateGovParams(params govv1types.Params) error
 	UpdateInflationParams(params infltypes.Params) error
 	UpdateRevenueParams(params revtypes.Params) error
+
+	// Close removes the node home directory created for the network
+	Close() error
 }
 
 var _ Network = (*IntegrationNetwork)(nil)
@@ -61,10 +170,43 @@ type IntegrationNetwork struct {
 	ctx        sdktypes.Context
 	validators []stakingtypes.Validator
 	app        *app.Evmos
+	db         dbm.DB
+
+	// snapshots holds the network states captured with Snapshot
+	snapshots []networkSnapshot
 
 	// This is only needed for IBC chain testing setup
 	valSet     *tmtypes.ValidatorSet
 	valSigners map[string]tmtypes.PrivValidator
+
+	// nextValSet is the validator set of the next block, which already
+	// includes the validator updates of the previous block
+	nextValSet *tmtypes.ValidatorSet
+
+	// genesisTotalSupply is the total supply calculated from the genesis balances
+	genesisTotalSupply sdktypes.Coins
+
+	// absentSigners holds the validators that don't sign the following blocks
+	absentSigners map[string]bool
+
+	// minGasPrices are the local min gas prices used on CheckTx
+	minGasPrices sdktypes.DecCoins
+
+	// nextProposer is the address of the validator set to propose the next block
+	nextProposer []byte
+
+	// txResponses records the responses of the delivered txs while it is not nil
+	txResponses []abcitypes.ResponseDeliverTx
+
+	// blockEvents holds the events emitted on the latest blocks by height
+	blockEvents map[int64][]abcitypes.Event
+
+	// blockTxs holds the txs delivered on the current block for the tx result hooks
+	blockTxs []blockTx
+
+	// tempHomePath is the node home directory created for the network, which is
+	// removed on Close
+	tempHomePath string
 }
 
 // New configures and initializes a new integration Network instance with
@@ -94,8 +236,6 @@ func New(opts ...ConfigOption) *IntegrationNetwork {
 }
 
 var (
-	// bondedAmt is the amount of tokens that each validator will have initially bonded
-	bondedAmt = sdktypes.TokensFromConsensusPower(1, types.PowerReduction)
 	// PrefundedAccountInitialBalance is the amount of tokens that each prefunded account has at genesis
 	PrefundedAccountInitialBalance = sdktypes.NewInt(int64(math.Pow10(18) * 4))
 )
@@ -103,50 +243,380 @@ var (
 // configureAndInitChain initializes the network with the given configuration.
 // It creates the genesis state and starts the network.
 func (n *IntegrationNetwork) configureAndInitChain() error {
+	// The EVM requires a chain ID with a valid EIP-155 number to sign transactions
+	eip155ChainID, err := evmostypes.ParseChainID(n.cfg.chainID)
+	if err != nil {
+		return fmt.Errorf("invalid chain ID %q, expected the format {identifier}_{EIP155 number}-{version}: %w", n.cfg.chainID, err)
+	}
+	n.cfg.eip155ChainID = eip155ChainID
+
+	if n.cfg.initialHeight < 1 {
+		return fmt.Errorf("invalid initial height %d: must be at least 1", n.cfg.initialHeight)
+	}
+
+	if n.cfg.clock != nil && n.cfg.genesisTime.IsZero() {
+		n.cfg.genesisTime = n.cfg.clock()
+	}
+
 	// Create funded accounts based on the config and
 	// create genesis accounts
 	coin := sdktypes.NewCoin(n.cfg.denom, PrefundedAccountInitialBalance)
 	genAccounts := createGenesisAccounts(n.cfg.preFundedAccounts)
+	if len(n.cfg.accountSequences) > 0 || len(n.cfg.accountNumbers) > 0 {
+		genAccounts, err = createGenesisAccountsWithMeta(n.cfg.preFundedAccounts, n.cfg.accountSequences, n.cfg.accountNumbers)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Deploy the ERC20 contracts of the native token pairs, which are
+	// included in the genesis together with the custom EVM contracts.
+	nativePairs, err := createNativeErc20Pairs(n.cfg.nativeErc20Pairs)
+	if err != nil {
+		return err
+	}
+	evmContracts, err := mergeGenesisContracts(n.cfg.evmContracts, nativePairs.contracts)
+	if err != nil {
+		return err
+	}
+	contractAccounts, err := createContractGenesisAccounts(evmContracts)
+	if err != nil {
+		return err
+	}
+	genAccounts = append(genAccounts, contractAccounts...)
+	if nativePairs.moduleAccount != nil {
+		genAccounts = append(genAccounts, nativePairs.moduleAccount)
+	}
+	for _, moduleAccount := range n.cfg.moduleAccounts {
+		genAccount, err := createModuleAccount(moduleAccount.Name, moduleAccount.Permissions...)
+		if err != nil {
+			return err
+		}
+		genAccounts = append(genAccounts, genAccount)
+	}
 	fundedAccountBalances := createBalances(n.cfg.preFundedAccounts, coin)
+	if len(n.cfg.preFundedCoins) > 0 {
+		fundedAccountBalances, err = createBalancesBatch(n.cfg.preFundedAccounts, n.cfg.preFundedCoins)
+		if err != nil {
+			return err
+		}
+	}
+	fundedAccountBalances = append(fundedAccountBalances, createVestingBalances(n.cfg.vestingAccounts)...)
+
+	// NOTE: CometBFT requires at least one validator to produce blocks, so a network
+	// without validators runs a single hidden validator with the minimum power.
+	if n.cfg.noValidators {
+		if n.cfg.amountOfValidators != 1 || len(n.cfg.validatorPowers) > 0 || len(n.cfg.validatorPrivVals) > 0 || len(n.cfg.jailedValidators) > 0 {
+			return fmt.Errorf("a network without validators cannot be combined with validator options")
+		}
+	}
 
 	// Create validator set with the amount of validators specified in the config
-	// with the default power of 1.
+	// with the default power of 1, or with the custom signers and powers if provided.
 	valSet, valSigners := createValidatorSetAndSigners(n.cfg.amountOfValidators)
-	totalBonded := bondedAmt.Mul(sdktypes.NewInt(int64(n.cfg.amountOfValidators)))
+	switch {
+	case len(n.cfg.validatorPrivVals) > 0 && len(n.cfg.validatorPowers) > 0:
+		valSet, valSigners, err = createValidatorSetFromPrivValsWithPower(n.cfg.validatorPrivVals, n.cfg.validatorPowers)
+	case len(n.cfg.validatorPrivVals) > 0:
+		valSet, valSigners, err = createValidatorSetFromPrivVals(n.cfg.validatorPrivVals)
+	case len(n.cfg.validatorPowers) > 0:
+		valSet, valSigners, err = createValidatorSetAndSignersWithPower(n.cfg.validatorPowers)
+	}
+	if err != nil {
+		return err
+	}
 
 	// Build staking type validators and delegations
-	validators, err := createStakingValidators(valSet.Validators, bondedAmt)
+	validators, err := createStakingValidators(valSet.Validators, n.cfg.validatorCommissions, n.cfg.genesisTime)
 	if err != nil {
 		return err
 	}
 
-	fundedAccountBalances = addBondedModuleAccountToFundedBalances(fundedAccountBalances, sdktypes.NewCoin(n.cfg.denom, totalBonded))
+	// Jail the validators specified in the config. Jailed validators are unbonded
+	// and therefore excluded from the validator set that produces blocks.
+	if err := jailStakingValidators(validators, n.cfg.jailedValidators); err != nil {
+		return err
+	}
 
-	delegations := createDelegations(valSet.Validators, genAccounts[0].GetAddress())
+	activeValSet, err := createActiveValidatorSet(valSet, validators)
+	if err != nil {
+		return err
+	}
+
+	totalBonded, totalNotBonded := sdktypes.ZeroInt(), sdktypes.ZeroInt()
+	for _, val := range validators {
+		if val.IsBonded() {
+			totalBonded = totalBonded.Add(val.Tokens)
+		} else {
+			totalNotBonded = totalNotBonded.Add(val.Tokens)
+		}
+	}
+
+	// The balances of the unbonding delegation entries are held by the not bonded pool
+	totalNotBonded = totalNotBonded.Add(getUnbondingDelegationsBalance(n.cfg.unbondingDelegations))
+
+	// NOTE: the delegations have to be created before the staking genesis is set
+	// because they update the delegator shares of the validators.
+	delegations, err := createDelegationsMulti(validators, genAccounts[0].GetAddress(), n.cfg.delegations)
+	if err != nil {
+		return err
+	}
 
 	// Create a new EvmosApp with the following params
-	evmosApp := createEvmosApp(n.cfg.chainID)
+	if err := n.createHomePath(); err != nil {
+		return err
+	}
+
+	feeMarketGenesis, err := getCustomGenesisState(n.cfg.customGenesisState, feemarkettypes.ModuleName, feemarkettypes.DefaultGenesisState())
+	if err != nil {
+		return err
+	}
+	if err := n.setMinGasPrices(feeMarketGenesis); err != nil {
+		return err
+	}
+
+	db := dbm.NewMemDB()
+	evmosApp := createEvmosApp(n.cfg.chainID, n.cfg.homePath, n.cfg.blockedAddresses, db, n.cfg.logger, n.cfg.anteHandler, baseapp.SetMinGasPrices(n.minGasPrices.String()))
+
+	// NOTE: the blocked addresses are set on the bank keeper when the app is
+	// created, so they can only be validated against the funded accounts afterwards.
+	fundedAccounts := append([]sdktypes.AccAddress{}, n.cfg.preFundedAccounts...)
+	for _, vestingAccount := range n.cfg.vestingAccounts {
+		fundedAccounts = append(fundedAccounts, vestingAccount.Address)
+	}
+	if err := validateBlockedAddresses(evmosApp, fundedAccounts); err != nil {
+		return err
+	}
+
+	// NOTE: the module hooks are disabled before the chain is initialized, so that
+	// they are also skipped on the BeginBlock of the genesis block.
+	if err := disableModuleHooks(evmosApp, n.cfg.disabledModuleHooks); err != nil {
+		return err
+	}
 
 	// Configure Genesis state
 	genesisState := app.NewDefaultGenesisState()
 
-	genesisState = setAuthGenesisState(evmosApp, genesisState, genAccounts)
+	authParams := AuthCustomGenesisState{
+		genAccounts:     genAccounts,
+		vestingAccounts: n.cfg.vestingAccounts,
+		genesisTime:     n.cfg.genesisTime,
+	}
+	genesisState, err = setAuthGenesisState(evmosApp, genesisState, authParams)
+	if err != nil {
+		return err
+	}
 
 	stakingParams := StakingCustomGenesisState{
-		denom:       n.cfg.denom,
-		validators:  validators,
-		delegations: delegations,
+		denom:                n.cfg.denom,
+		genesisTime:          n.cfg.genesisTime,
+		validators:           validators,
+		delegations:          delegations,
+		unbondingDelegations: n.cfg.unbondingDelegations,
+		redelegations:        n.cfg.redelegations,
+		unbondingTime:        n.cfg.unbondingTime,
+		maxValidators:        n.cfg.maxValidators,
+		maxEntries:           n.cfg.maxEntries,
+		historicalEntries:    n.cfg.historicalEntries,
+		ibcSeeded:            n.cfg.customGenesisState[ibcexported.ModuleName] != nil,
+	}
+	genesisState, err = setStakingGenesisState(evmosApp, genesisState, stakingParams)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the staking pools are funded after the staking genesis is set
+	// so that their balances are validated against its bond denom.
+	fundedAccountBalances, err = addStakingPoolBalances(
+		fundedAccountBalances,
+		sdktypes.NewCoin(n.cfg.denom, totalBonded),
+		sdktypes.NewCoin(n.cfg.denom, totalNotBonded),
+		getBondDenom(evmosApp, genesisState),
+	)
+	if err != nil {
+		return err
 	}
-	genesisState = setStakingGenesisState(evmosApp, genesisState, stakingParams)
 
-	genesisState = setInflationGenesisState(evmosApp, genesisState)
+	// NOTE: the slashing genesis has to be set after the staking genesis
+	// because the signing infos refer to the genesis validators.
+	slashingParams := SlashingCustomGenesisState{
+		signedBlocksWindow: n.cfg.signedBlocksWindow,
+		minSignedPerWindow: n.cfg.minSignedPerWindow,
+	}
+	genesisState, err = setSlashingGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, slashingParams)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the evidence genesis has to be set after the staking genesis
+	// because the equivocations refer to the genesis validators.
+	genesisState, err = setEvidenceGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, n.cfg.initialHeight)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the distribution genesis has to be set after the staking genesis
+	// because the outstanding rewards refer to the genesis validators.
+	distrParams := DistributionCustomGenesisState{
+		communityPool:     n.cfg.communityPool,
+		withdrawAddresses: n.cfg.withdrawAddresses,
+		fundedAccounts:    fundedAccounts,
+	}
+	genesisState, err = setDistributionGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, distrParams)
+	if err != nil {
+		return err
+	}
+
+	genesisState, err = setCapabilityGenesisState(evmosApp, genesisState, n.cfg.customGenesisState)
+	if err != nil {
+		return err
+	}
+
+	genesisState, err = setIBCGenesisState(evmosApp, genesisState, n.cfg.customGenesisState)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the gov genesis has to be set after the staking genesis
+	// because the min deposit must be in the bond denom.
+	govParams := GovCustomGenesisState{
+		params:                 n.cfg.govParams,
+		depositPeriodProposals: n.cfg.depositPeriodProposals,
+		genesisTime:            n.cfg.genesisTime,
+	}
+	genesisState, err = setGovGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, govParams)
+	if err != nil {
+		return err
+	}
+
+	epochsParams := EpochsCustomGenesisState{
+		epochs: n.cfg.epochs,
+	}
+	genesisState, err = setEpochsGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, epochsParams)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the inflation genesis has to be set after the epochs genesis
+	// because the inflation epoch identifier must be a genesis epoch.
+	inflationParams := InflationCustomGenesisState{
+		enabled:         n.cfg.inflationEnabled,
+		epochIdentifier: n.cfg.inflationEpochIdentifier,
+		epochsPerPeriod: n.cfg.inflationEpochsPerPeriod,
+	}
+	genesisState, err = setInflationGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, inflationParams)
+	if err != nil {
+		return err
+	}
+
+	feeMarketParams := FeeMarketCustomGenesisState{
+		blockGasWanted: n.cfg.blockGasWanted,
+		maxBlockGas:    -1,
+	}
+	if n.cfg.consensusParams.Block != nil {
+		feeMarketParams.maxBlockGas = n.cfg.consensusParams.Block.MaxGas
+	}
+	genesisState, err = setFeeMarketGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, feeMarketParams)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the EVM genesis has to be set after the auth genesis because
+	// the EVM genesis accounts must have a matching EthAccount.
+	evmParams := EVMCustomGenesisState{
+		contracts:       evmContracts,
+		disabled:        n.cfg.evmDisabled,
+		paramsModifiers: n.cfg.evmParamsModifiers,
+	}
+	genesisState, err = setEVMGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, evmParams)
+	if err != nil {
+		return err
+	}
+
+	// Fund the distribution module account to back the outstanding rewards and
+	// the community pool included in the distribution genesis.
+	distrGenesis := &distrtypes.GenesisState{}
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[distrtypes.ModuleName], distrGenesis)
+	fundedAccountBalances = addDistributionModuleAccountToFundedBalances(fundedAccountBalances, distrGenesis)
+	if err := validateDistributionModuleBalance(fundedAccountBalances, distrGenesis); err != nil {
+		return err
+	}
+
+	// Fund the gov module account to back the deposits included in the gov genesis.
+	govGenesis := &govv1types.GenesisState{}
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[govtypes.ModuleName], govGenesis)
+	fundedAccountBalances = addGovModuleAccountToFundedBalances(fundedAccountBalances, govGenesis)
+
+	// Fund the fee collector module account, which fees are allocated on the first block.
+	fundedAccountBalances, err = addFeeCollectorModuleAccountToFundedBalances(fundedAccountBalances, n.cfg.feeCollectorBalance)
+	if err != nil {
+		return err
+	}
 
 	totalSupply := calculateTotalSupply(fundedAccountBalances)
+	denomMetadata := append([]banktypes.Metadata{}, n.cfg.denomMetadata...)
 	bankParams := BankCustomGenesisState{
 		totalSupply: totalSupply,
 		balances:    fundedAccountBalances,
+		metadata:    append(denomMetadata, nativePairs.metadata...),
+		sendEnabled: n.cfg.sendEnabled,
+	}
+	genesisState, err = setBankGenesisState(evmosApp, genesisState, bankParams)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the EVM denom has to be validated after the bank genesis is set
+	// because its metadata is part of the bank genesis.
+	if err := validateEVMDenomMetadata(evmosApp, genesisState); err != nil {
+		return err
+	}
+
+	// NOTE: the vesting balances have to be validated after the bank genesis is set
+	// because the balances of the vesting accounts are part of the bank genesis.
+	if err := validateLockedVestingBalances(evmosApp, genesisState, n.cfg.genesisTime); err != nil {
+		return err
+	}
+
+	// NOTE: the transfer genesis has to be set after the bank genesis because
+	// the escrowed amounts must be backed by the bank supply.
+	genesisState, err = setTransferGenesisState(evmosApp, genesisState, n.cfg.customGenesisState)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the feegrant genesis has to be set after the bank genesis because
+	// the granters and grantees must be funded accounts.
+	genesisState, err = setFeeGrantGenesisState(evmosApp, genesisState, n.cfg.customGenesisState)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the authz genesis has to be set after the bank genesis because
+	// the granters and grantees must be funded accounts.
+	genesisState, err = setAuthzGenesisState(evmosApp, genesisState, n.cfg.customGenesisState, n.cfg.genesisTime)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the erc20 genesis has to be set after the bank genesis because
+	// the token pairs refer to the bank denominations.
+	erc20Params := Erc20CustomGenesisState{
+		tokenPairs: nativePairs.tokenPairs,
+	}
+	genesisState, err = setErc20GenesisState(evmosApp, genesisState, n.cfg.customGenesisState, erc20Params)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the genesis mutators have to run after all the module genesis
+	// states are set, so that they can modify any of them.
+	for i, mutator := range n.cfg.genesisMutators {
+		genesisState = mutator(genesisState)
+		if genesisState == nil {
+			return fmt.Errorf("genesis mutator %d returned a nil genesis state", i)
+		}
 	}
-	genesisState = setBankGenesisState(evmosApp, genesisState, bankParams)
 
 	// Init chain
 	stateBytes, err := json.MarshalIndent(genesisState, "", " ")
@@ -154,12 +624,14 @@ func (n *IntegrationNetwork) configureAndInitChain() error {
 		return err
 	}
 
-	consnsusParams := app.DefaultConsensusParams
+	consnsusParams := n.cfg.consensusParams
 	evmosApp.InitChain(
 		abcitypes.RequestInitChain{
+			Time:            n.cfg.genesisTime,
 			ChainId:         n.cfg.chainID,
+			InitialHeight:   n.cfg.initialHeight,
 			Validators:      []abcitypes.ValidatorUpdate{},
-			ConsensusParams: app.DefaultConsensusParams,
+			ConsensusParams: consnsusParams,
 			AppStateBytes:   stateBytes,
 		},
 	)
@@ -169,24 +641,41 @@ func (n *IntegrationNetwork) configureAndInitChain() error {
 	header := tmproto.Header{
 		ChainID:            n.cfg.chainID,
 		Height:             evmosApp.LastBlockHeight() + 1,
+		Time:               n.cfg.genesisTime,
 		AppHash:            evmosApp.LastCommitID().Hash,
-		ValidatorsHash:     valSet.Hash(),
-		NextValidatorsHash: valSet.Hash(),
-		ProposerAddress:    valSet.Proposer.Address,
+		ValidatorsHash:     activeValSet.Hash(),
+		NextValidatorsHash: activeValSet.Hash(),
+		ProposerAddress:    activeValSet.Proposer.Address,
 	}
-	evmosApp.BeginBlock(abcitypes.RequestBeginBlock{Header: header})
+	beginBlockRes := evmosApp.BeginBlock(abcitypes.RequestBeginBlock{Header: header})
+	n.recordBlockEvents(header.Height, beginBlockRes.Events)
 
 	// Set networks global parameters
 	n.app = evmosApp
+	n.db = db
 	// TODO - this might not be the best way to initilize the context
 	n.ctx = evmosApp.BaseApp.NewContext(false, header)
 	n.ctx = n.ctx.WithConsensusParams(consnsusParams)
 	n.ctx = n.ctx.WithBlockGasMeter(sdktypes.NewInfiniteGasMeter())
 
 	n.validators = validators
-	n.valSet = valSet
+	if n.cfg.noValidators {
+		n.validators = []stakingtypes.Validator{}
+	}
+	n.genesisTotalSupply = totalSupply
+	n.setValidatorSet(activeValSet)
 	n.valSigners = valSigners
 
+	// The staking hooks called on InitGenesis reinitialize the distribution
+	// records of the genesis validators, so the custom ones are set again.
+	if err := setDistributionValidatorRecords(n.ctx, evmosApp, distrGenesis); err != nil {
+		return err
+	}
+
+	if err := n.setupInitializedChain(header); err != nil {
+		return err
+	}
+
 	// Register EVMOS in denom metadata
 	evmosMetadata := banktypes.Metadata{
 		Description: "The native token of Evmos",
@@ -212,11 +701,112 @@ func (n *IntegrationNetwork) configureAndInitChain() error {
 	return nil
 }
 
+// setMinGasPrices sets the local min gas prices used on CheckTx. The CheckTx
+// context uses the min gas price of the given fee market genesis as the local
+// min gas price, like a node configured to match the global fee, unless
+// custom local min gas prices are configured.
+func (n *IntegrationNetwork) setMinGasPrices(feeMarketGenesis *feemarkettypes.GenesisState) error {
+	n.minGasPrices = sdktypes.DecCoins{}
+	switch {
+	case n.cfg.minGasPrices != nil:
+		if err := n.cfg.minGasPrices.Validate(); err != nil {
+			return fmt.Errorf("invalid min gas prices %s: %w", n.cfg.minGasPrices, err)
+		}
+		n.minGasPrices = n.cfg.minGasPrices
+	case feeMarketGenesis.Params.MinGasPrice.IsPositive():
+		n.minGasPrices = sdktypes.NewDecCoins(sdktypes.NewDecCoinFromDec(n.cfg.denom, feeMarketGenesis.Params.MinGasPrice))
+	}
+	return nil
+}
+
+// setupInitializedChain applies the configuration that can only be set once the
+// chain is initialized, on the context of the first block with the given header.
+func (n *IntegrationNetwork) setupInitializedChain(header tmproto.Header) error {
+	// NOTE: the staking module tracks the historical info from the first block,
+	// so the one of the genesis height is stored once the app is initialized.
+	if genesisHeight := header.Height - 1; genesisHeight > 0 {
+		genesisHeader := header
+		genesisHeader.Height = genesisHeight
+		genesisHeader.AppHash = nil
+		genesisHeader.ProposerAddress = nil
+		setGenesisHistoricalInfo(n.ctx, n.app, genesisHeader)
+	}
+
+	// NOTE: the available precompiles are kept in memory by the EVM keeper,
+	// so the custom precompiles can only be added once the app is created.
+	if err := addCustomPrecompiles(n.ctx, n.app, n.cfg.customPrecompiles); err != nil {
+		return err
+	}
+
+	// NOTE: the ERC20 precompiles are created from the token pairs of the erc20
+	// genesis, so they can only be added once the app is initialized.
+	erc20Precompiles, err := createErc20Precompiles(n.ctx, n.app, n.cfg.erc20Precompiles)
+	if err != nil {
+		return err
+	}
+	if err := addCustomPrecompiles(n.ctx, n.app, erc20Precompiles); err != nil {
+		return err
+	}
+
+	if n.cfg.upgradePlan != nil {
+		if err := scheduleUpgradePlan(n.ctx, n.app, *n.cfg.upgradePlan, n.cfg.upgradeHandler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createHomePath creates a unique node home directory for the network if none is
+// configured, so that networks running in parallel don't share their config files.
+func (n *IntegrationNetwork) createHomePath() error {
+	if n.cfg.homePath != "" {
+		return nil
+	}
+	homePath, err := os.MkdirTemp("", "evmos-network-")
+	if err != nil {
+		return fmt.Errorf("failed to create node home directory: %w", err)
+	}
+	n.cfg.homePath = homePath
+	n.tempHomePath = homePath
+	return nil
+}
+
+// Close removes the node home directory created for the network, if any. A custom
+// home path set with WithHomePath is kept. Clones share the home directory of the
+// network they were created from, so they must not be used after it is closed.
+func (n *IntegrationNetwork) Close() error {
+	if n.tempHomePath == "" {
+		return nil
+	}
+	if err := os.RemoveAll(n.tempHomePath); err != nil {
+		return fmt.Errorf("failed to remove node home directory: %w", err)
+	}
+	n.tempHomePath = ""
+	return nil
+}
+
 // GetContext returns the network's context
 func (n *IntegrationNetwork) GetContext() sdktypes.Context {
 	return n.ctx
 }
 
+// AppHash returns the app hash of the latest committed block, i.e. the data of the
+// last commit response. Two networks with the same configuration that run the same
+// transactions have the same app hash at each height.
+func (n *IntegrationNetwork) AppHash() []byte {
+	return n.app.LastCommitID().Hash
+}
+
+// GetContextAtHeight returns a query context for the committed state at the given
+// block height. It returns an error if the height is not positive, is in the future
+// or has been pruned.
+func (n *IntegrationNetwork) GetContextAtHeight(height int64) (sdktypes.Context, error) {
+	if height <= 0 {
+		return sdktypes.Context{}, fmt.Errorf("invalid height %d: must be positive", height)
+	}
+	return n.app.CreateQueryContext(height, false)
+}
+
 // GetChainID returns the network's chainID
 func (n *IntegrationNetwork) GetChainID() string {
 	return n.cfg.chainID
@@ -227,6 +817,32 @@ func (n *IntegrationNetwork) GetEIP155ChainID() *big.Int {
 	return n.cfg.eip155ChainID
 }
 
+// GetGenesisTime returns the block time of the network's genesis block
+func (n *IntegrationNetwork) GetGenesisTime() time.Time {
+	return n.cfg.genesisTime
+}
+
+// BlockTime returns the block time of the network's current block
+func (n *IntegrationNetwork) BlockTime() time.Time {
+	return n.ctx.BlockTime()
+}
+
+// GetConsensusParams returns the network's consensus params
+func (n *IntegrationNetwork) GetConsensusParams() *tmproto.ConsensusParams {
+	return n.ctx.ConsensusParams()
+}
+
+// EncodingConfig returns the encoding config used by the network's app, so that
+// messages are marshaled and unpacked with the same codec and interface registry.
+func (n *IntegrationNetwork) EncodingConfig() params.EncodingConfig {
+	return params.EncodingConfig{
+		InterfaceRegistry: n.app.InterfaceRegistry(),
+		Codec:             n.app.AppCodec(),
+		TxConfig:          n.app.GetTxConfig(),
+		Amino:             n.app.LegacyAmino(),
+	}
+}
+
 // GetDenom returns the network's denom
 func (n *IntegrationNetwork) GetDenom() string {
 	return n.cfg.denom
@@ -241,13 +857,36 @@ func (n *IntegrationNetwork) GetValidators() []stakingtypes.Validator {
 // TODO - this should be change to gRPC
 func (n *IntegrationNetwork) BroadcastTxSync(txBytes []byte) (abcitypes.ResponseDeliverTx, error) {
 	req := abcitypes.RequestDeliverTx{Tx: txBytes}
-	return n.app.BaseApp.DeliverTx(req), nil
+	res := n.app.BaseApp.DeliverTx(req)
+	if n.txResponses != nil {
+		n.txResponses = append(n.txResponses, res)
+	}
+	n.recordBlockEvents(n.ctx.BlockHeight(), res.Events)
+	if len(n.cfg.txResultHooks) > 0 {
+		n.blockTxs = append(n.blockTxs, blockTx{txBytes: txBytes, res: res})
+	}
+	return res, nil
+}
+
+// CheckTx runs the given txBytes through the mempool checks, including the ante handler,
+// without including the transaction in a block. The local min gas prices are set to
+// the fee market min gas price, or to the ones configured with WithMinGasPrices.
+func (n *IntegrationNetwork) CheckTx(txBytes []byte) (abcitypes.ResponseCheckTx, error) {
+	req := abcitypes.RequestCheckTx{Tx: txBytes, Type: abcitypes.CheckTxType_New}
+	return n.app.BaseApp.CheckTx(req), nil
+}
+
+// ReCheckTx runs the given txBytes through the mempool checks again, as done with the
+// transactions that remain in the mempool after a block is committed.
+func (n *IntegrationNetwork) ReCheckTx(txBytes []byte) (abcitypes.ResponseCheckTx, error) {
+	req := abcitypes.RequestCheckTx{Tx: txBytes, Type: abcitypes.CheckTxType_Recheck}
+	return n.app.BaseApp.CheckTx(req), nil
 }
 
 // Simulate simulates the given txBytes to the network and returns the simulated response.
 // TODO - this should be change to gRPC
 func (n *IntegrationNetwork) Simulate(txBytes []byte) (*txtypes.SimulateResponse, error) {
-	gas, result, err := n.app.BaseApp.Simulate(txBytes)
+	gas, result, err := n.SimulateTx(txBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -256,3 +895,11 @@ func (n *IntegrationNetwork) Simulate(txBytes []byte) (*txtypes.SimulateResponse
 		Result:  result,
 	}, nil
 }
+
+// SimulateTx runs the given txBytes in simulation mode on top of the last committed state
+// and returns the gas info and result without committing any state change. The EVM
+// messages are executed by the EVM keeper, so the gas used reflects the actual execution,
+// in the same way as the gas estimation of the JSON-RPC server.
+func (n *IntegrationNetwork) SimulateTx(txBytes []byte) (sdktypes.GasInfo, *sdktypes.Result, error) {
+	return n.app.BaseApp.Simulate(txBytes)
+}
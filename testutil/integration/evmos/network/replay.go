@@ -0,0 +1,94 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+)
+
+// replayConfig defines how the transactions are replayed by ReplayTxs
+type replayConfig struct {
+	bestEffort bool
+}
+
+// ReplayOption defines a function that modifies how the transactions are replayed
+type ReplayOption func(*replayConfig)
+
+// WithBestEffort continues replaying the following transactions when a transaction fails,
+// so that the responses of all the transactions are returned.
+func WithBestEffort() ReplayOption {
+	return func(cfg *replayConfig) {
+		cfg.bestEffort = true
+	}
+}
+
+// ReplayTxs reads the signed transactions of the file at the given path, one base64 encoded
+// transaction per line, and delivers them in order, committing a block after each one.
+// Empty lines are skipped.
+//
+// It returns the responses of the delivered transactions. It stops on the first transaction
+// that fails and returns an error with its index, unless the best effort option is set.
+// It always returns an error if the file cannot be read or if a line is not valid base64.
+func (n *IntegrationNetwork) ReplayTxs(path string, opts ...ReplayOption) ([]abcitypes.ResponseDeliverTx, error) {
+	cfg := replayConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	txs, err := readReplayTxs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]abcitypes.ResponseDeliverTx, 0, len(txs))
+	for i, txBytes := range txs {
+		res, err := n.BroadcastTxSync(txBytes)
+		if err != nil {
+			return responses, fmt.Errorf("failed to deliver tx %d: %w", i, err)
+		}
+		responses = append(responses, res)
+
+		if err := n.NextBlock(); err != nil {
+			return responses, fmt.Errorf("failed to commit block after tx %d: %w", i, err)
+		}
+		if res.IsErr() && !cfg.bestEffort {
+			return responses, fmt.Errorf("tx %d failed with code %d: %s", i, res.Code, res.Log)
+		}
+	}
+	return responses, nil
+}
+
+// readReplayTxs reads the base64 encoded transactions of the file at the given path
+func readReplayTxs(path string) ([][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open txs file: %w", err)
+	}
+	defer file.Close()
+
+	var txs [][]byte
+	scanner := bufio.NewScanner(file)
+	// NOTE: the EVM txs deploying contracts can exceed the default max line size
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		encoded := strings.TrimSpace(scanner.Text())
+		if encoded == "" {
+			continue
+		}
+		txBytes, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 tx on line %d: %w", line, err)
+		}
+		txs = append(txs, txBytes)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read txs file: %w", err)
+	}
+	return txs, nil
+}
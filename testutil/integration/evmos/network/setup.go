@@ -3,46 +3,153 @@
 package network
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/evmos/evmos/v16/app"
+	"github.com/evmos/evmos/v16/crypto/ethsecp256k1"
 	"github.com/evmos/evmos/v16/encoding"
+	evmostypes "github.com/evmos/evmos/v16/types"
 
 	"cosmossdk.io/simapp"
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/testutil/mock"
 
 	sdkmath "cosmossdk.io/math"
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/cometbft/cometbft/libs/log"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	tmtypes "github.com/cometbft/cometbft/types"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
 	simutils "github.com/cosmos/cosmos-sdk/testutil/sims"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	sdkvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	evidencetypes "github.com/cosmos/cosmos-sdk/x/evidence/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1types "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	ibcexported "github.com/cosmos/ibc-go/v7/modules/core/exported"
+	ibccoretypes "github.com/cosmos/ibc-go/v7/modules/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	epochstypes "github.com/evmos/evmos/v16/x/epochs/types"
+	erc20types "github.com/evmos/evmos/v16/x/erc20/types"
+	evmtypes "github.com/evmos/evmos/v16/x/evm/types"
+	feemarkettypes "github.com/evmos/evmos/v16/x/feemarket/types"
 	infltypes "github.com/evmos/evmos/v16/x/inflation/v1/types"
+	vestingtypes "github.com/evmos/evmos/v16/x/vesting/types"
 )
 
 // createValidatorSetAndSigners creates validator set with the amount of validators specified
 // with the default power of 1.
 func createValidatorSetAndSigners(numberOfValidators int) (*tmtypes.ValidatorSet, map[string]tmtypes.PrivValidator) {
-	// Create validator set
+	powers := make([]int64, numberOfValidators)
+	for i := range powers {
+		powers[i] = 1
+	}
+
+	// NOTE: the error can be safely ignored because all powers are positive
+	valSet, signers, _ := createValidatorSetAndSignersWithPower(powers)
+	return valSet, signers
+}
+
+// createValidatorSetAndSignersWithPower creates a validator set with one validator
+// for each of the given voting powers. It returns an error if any of the powers is
+// not positive.
+func createValidatorSetAndSignersWithPower(powers []int64) (*tmtypes.ValidatorSet, map[string]tmtypes.PrivValidator, error) {
+	privVals := make([]tmtypes.PrivValidator, 0, len(powers))
+	for range powers {
+		privVals = append(privVals, mock.NewPV())
+	}
+	return createValidatorSetFromPrivValsWithPower(privVals, powers)
+}
+
+// createValidatorSetFromPrivVals creates a validator set with one validator for each
+// of the given signers, with the default power of 1.
+func createValidatorSetFromPrivVals(privVals []tmtypes.PrivValidator) (*tmtypes.ValidatorSet, map[string]tmtypes.PrivValidator, error) {
+	powers := make([]int64, len(privVals))
+	for i := range powers {
+		powers[i] = 1
+	}
+	return createValidatorSetFromPrivValsWithPower(privVals, powers)
+}
+
+// createValidatorSetFromPrivValsWithPower creates a validator set with one validator
+// for each of the given signers and voting powers. It returns an error if the amount
+// of signers and powers differ, if any of the powers is not positive or if a signer
+// is provided more than once.
+func createValidatorSetFromPrivValsWithPower(privVals []tmtypes.PrivValidator, powers []int64) (*tmtypes.ValidatorSet, map[string]tmtypes.PrivValidator, error) {
+	numberOfValidators := len(privVals)
+	if len(powers) != numberOfValidators {
+		return nil, nil, fmt.Errorf("expected %d powers, one per validator, got %d", numberOfValidators, len(powers))
+	}
+
 	tmValidators := make([]*tmtypes.Validator, 0, numberOfValidators)
 	signers := make(map[string]tmtypes.PrivValidator, numberOfValidators)
 
-	for i := 0; i < numberOfValidators; i++ {
-		privVal := mock.NewPV()
-		pubKey, _ := privVal.GetPubKey()
-		validator := tmtypes.NewValidator(pubKey, 1)
+	for i, privVal := range privVals {
+		power := powers[i]
+		if power <= 0 {
+			return nil, nil, fmt.Errorf("invalid power %d for validator %d: power must be positive", power, i)
+		}
+
+		pubKey, err := privVal.GetPubKey()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get public key of validator %d: %w", i, err)
+		}
+
+		address := pubKey.Address().String()
+		if _, found := signers[address]; found {
+			return nil, nil, fmt.Errorf("duplicate signer for validator %d with address %s", i, address)
+		}
+
+		validator := tmtypes.NewValidator(pubKey, power)
 		tmValidators = append(tmValidators, validator)
-		signers[pubKey.Address().String()] = privVal
+		signers[address] = privVal
+	}
+
+	return tmtypes.NewValidatorSet(tmValidators), signers, nil
+}
+
+// deterministicPrivVals returns the given amount of ed25519 signers whose keys are
+// derived from the given seed, so that the consensus addresses are stable across runs.
+func deterministicPrivVals(seed int64, n int) []tmtypes.PrivValidator {
+	privVals := make([]tmtypes.PrivValidator, 0, n)
+	for i := 0; i < n; i++ {
+		secret := []byte(fmt.Sprintf("validator-%d-%d", seed, i))
+		privVals = append(privVals, mock.PV{PrivKey: ed25519.GenPrivKeyFromSecret(secret)})
 	}
+	return privVals
+}
 
-	return tmtypes.NewValidatorSet(tmValidators), signers
+// DeterministicAccounts returns the given amount of account addresses and their
+// eth_secp256k1 private keys, derived from the given seed so that the accounts
+// are stable across runs. The keys allow to sign transactions from the accounts
+// funded with WithDeterministicPreFundedAccounts and the same seed.
+func DeterministicAccounts(seed int64, n int) ([]sdktypes.AccAddress, []*ethsecp256k1.PrivKey) {
+	accounts := make([]sdktypes.AccAddress, 0, n)
+	privKeys := make([]*ethsecp256k1.PrivKey, 0, n)
+	for i := 0; i < n; i++ {
+		privKey := &ethsecp256k1.PrivKey{
+			Key: crypto.Keccak256([]byte(fmt.Sprintf("account-%d-%d", seed, i))),
+		}
+		accounts = append(accounts, sdktypes.AccAddress(privKey.PubKey().Address()))
+		privKeys = append(privKeys, privKey)
+	}
+	return accounts, privKeys
 }
 
 // createGenesisAccounts returns a slice of genesis accounts from the given
@@ -57,14 +164,174 @@ func createGenesisAccounts(accounts []sdktypes.AccAddress) []authtypes.GenesisAc
 	return genAccounts
 }
 
+// ModuleAccount defines a standalone module account that is created at genesis
+// with the given name and permissions.
+type ModuleAccount struct {
+	Name        string
+	Permissions []string
+}
+
+// createModuleAccount returns a module account genesis entry with the given name and
+// permissions. It returns an error if the name collides with one of the app's module
+// accounts or if any of the permissions is unknown.
+func createModuleAccount(name string, perms ...string) (*authtypes.ModuleAccount, error) {
+	if _, found := app.GetMaccPerms()[name]; found {
+		return nil, fmt.Errorf("module account %s collides with a module account of the app", name)
+	}
+	for _, perm := range perms {
+		switch perm {
+		case authtypes.Minter, authtypes.Burner, authtypes.Staking:
+		default:
+			return nil, fmt.Errorf("invalid permission %q for module account %s", perm, name)
+		}
+	}
+
+	moduleAccount := authtypes.NewEmptyModuleAccount(name, perms...)
+	if err := moduleAccount.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid module account %s: %w", name, err)
+	}
+	return moduleAccount, nil
+}
+
+// createGenesisAccountsWithMeta returns a slice of genesis accounts from the given
+// account addresses, with the given sequences and account numbers. It returns an
+// error if the amount of sequences or account numbers doesn't match the amount of
+// accounts, or if any account number is duplicated.
+func createGenesisAccountsWithMeta(accounts []sdktypes.AccAddress, seqs, numbers []uint64) ([]authtypes.GenesisAccount, error) {
+	numberOfAccounts := len(accounts)
+	if len(seqs) != numberOfAccounts {
+		return nil, fmt.Errorf("expected %d sequences, one per account, got %d", numberOfAccounts, len(seqs))
+	}
+	if len(numbers) != numberOfAccounts {
+		return nil, fmt.Errorf("expected %d account numbers, one per account, got %d", numberOfAccounts, len(numbers))
+	}
+
+	accountNumbers := make(map[uint64]bool, numberOfAccounts)
+	genAccounts := make([]authtypes.GenesisAccount, 0, numberOfAccounts)
+	for i, acc := range accounts {
+		if accountNumbers[numbers[i]] {
+			return nil, fmt.Errorf("duplicate account number %d for account %s", numbers[i], acc)
+		}
+		accountNumbers[numbers[i]] = true
+
+		baseAcc := authtypes.NewBaseAccount(acc, nil, numbers[i], seqs[i])
+		genAccounts = append(genAccounts, baseAcc)
+	}
+	return genAccounts, nil
+}
+
+// createEthGenesisAccounts returns a slice of EthAccount genesis accounts from the given
+// account addresses, code hashes and nonces. Accounts with the empty code hash are EOAs,
+// while the rest are contract accounts. The nonce of each account is set as its sequence,
+// which is the nonce the EVM keeper reports for the account. If no nonces are provided,
+// all accounts start with a zero nonce.
+// It returns an error if the amount of code hashes or nonces doesn't match the amount of accounts.
+func createEthGenesisAccounts(accounts []sdktypes.AccAddress, codeHashes []common.Hash, nonces []uint64) ([]authtypes.GenesisAccount, error) {
+	numberOfAccounts := len(accounts)
+	if len(codeHashes) != numberOfAccounts {
+		return nil, fmt.Errorf("expected %d code hashes, one per account, got %d", numberOfAccounts, len(codeHashes))
+	}
+	if len(nonces) > 0 && len(nonces) != numberOfAccounts {
+		return nil, fmt.Errorf("expected %d nonces, one per account, got %d", numberOfAccounts, len(nonces))
+	}
+
+	genAccounts := make([]authtypes.GenesisAccount, 0, numberOfAccounts)
+	for i, acc := range accounts {
+		var nonce uint64
+		if len(nonces) > 0 {
+			nonce = nonces[i]
+		}
+		ethAcc := &evmostypes.EthAccount{
+			BaseAccount: authtypes.NewBaseAccount(acc, nil, 0, nonce),
+			CodeHash:    codeHashes[i].Hex(),
+		}
+		genAccounts = append(genAccounts, ethAcc)
+	}
+	return genAccounts, nil
+}
+
+// VestingAccount defines a clawback vesting account that is created at genesis
+// with the given funder and lockup and vesting schedules.
+type VestingAccount struct {
+	Address        sdktypes.AccAddress
+	Funder         sdktypes.AccAddress
+	LockupPeriods  sdkvesting.Periods
+	VestingPeriods sdkvesting.Periods
+}
+
+// OriginalVesting returns the total amount of coins that vest on the account.
+// If the vesting schedule is absent, the lockup schedule amount is used instead.
+func (va VestingAccount) OriginalVesting() sdktypes.Coins {
+	if len(va.VestingPeriods) == 0 {
+		return va.LockupPeriods.TotalAmount()
+	}
+	return va.VestingPeriods.TotalAmount()
+}
+
+// createClawbackVestingAccounts returns a slice of clawback vesting genesis accounts
+// for the given vesting accounts, with their schedules starting at the given start time.
+// An absent lockup or vesting schedule defaults to an instant one, following the
+// behavior of the vesting module. It returns an error if the funder is not set or
+// if any of the resulting accounts is invalid.
+func createClawbackVestingAccounts(vestingAccounts []VestingAccount, startTime time.Time) ([]authtypes.GenesisAccount, error) {
+	genAccounts := make([]authtypes.GenesisAccount, 0, len(vestingAccounts))
+	for _, va := range vestingAccounts {
+		if va.Funder.Empty() {
+			return nil, fmt.Errorf("funder address not set for vesting account %s", va.Address)
+		}
+
+		originalVesting := va.OriginalVesting()
+		lockupPeriods, vestingPeriods := va.LockupPeriods, va.VestingPeriods
+		if len(lockupPeriods) == 0 {
+			lockupPeriods = sdkvesting.Periods{{Length: 0, Amount: originalVesting}}
+		}
+		if len(vestingPeriods) == 0 {
+			vestingPeriods = sdkvesting.Periods{{Length: 0, Amount: originalVesting}}
+		}
+
+		vestingAcc := vestingtypes.NewClawbackVestingAccount(
+			authtypes.NewBaseAccount(va.Address, nil, 0, 0),
+			va.Funder,
+			originalVesting,
+			startTime,
+			lockupPeriods,
+			vestingPeriods,
+		)
+		if err := vestingAcc.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid vesting account %s: %w", va.Address, err)
+		}
+		genAccounts = append(genAccounts, vestingAcc)
+	}
+	return genAccounts, nil
+}
+
+// createVestingBalances creates balances for the given vesting accounts, funding
+// each account with its original vesting amount.
+func createVestingBalances(vestingAccounts []VestingAccount) []banktypes.Balance {
+	balances := make([]banktypes.Balance, 0, len(vestingAccounts))
+	for _, va := range vestingAccounts {
+		balances = append(balances, banktypes.Balance{
+			Address: va.Address.String(),
+			Coins:   va.OriginalVesting(),
+		})
+	}
+	return balances
+}
+
 // createBalances creates balances for the given accounts and coin
 func createBalances(accounts []sdktypes.AccAddress, coin sdktypes.Coin) []banktypes.Balance {
+	return createBalancesMulti(accounts, sdktypes.NewCoins(coin))
+}
+
+// createBalancesMulti creates balances for the given accounts, funding each
+// account with the full set of the given coins
+func createBalancesMulti(accounts []sdktypes.AccAddress, coins sdktypes.Coins) []banktypes.Balance {
 	numberOfAccounts := len(accounts)
 	fundedAccountBalances := make([]banktypes.Balance, 0, numberOfAccounts)
 	for _, acc := range accounts {
 		balance := banktypes.Balance{
 			Address: acc.String(),
-			Coins:   sdktypes.NewCoins(coin),
+			Coins:   coins,
 		}
 
 		fundedAccountBalances = append(fundedAccountBalances, balance)
@@ -72,20 +339,79 @@ func createBalances(accounts []sdktypes.AccAddress, coin sdktypes.Coin) []bankty
 	return fundedAccountBalances
 }
 
-// createEvmosApp creates an evmos app
-func createEvmosApp(chainID string) *app.Evmos {
+// createBalancesBatch creates balances for the given accounts, funding each account with
+// the full set of the given coins. It is meant for funding many accounts, e.g. on airdrop
+// simulations: the coins are validated once instead of sanitized per account, and the
+// balances and their coins are allocated at once. Each balance still holds its own copy
+// of the coins, so that modifying one of them doesn't affect the others.
+// It returns an error if the coins are invalid, e.g. unsorted or with duplicate denoms.
+func createBalancesBatch(accounts []sdktypes.AccAddress, coins sdktypes.Coins) ([]banktypes.Balance, error) {
+	if err := coins.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid coins %s: %w", coins, err)
+	}
+
+	numberOfCoins := len(coins)
+	accountsCoins := make(sdktypes.Coins, len(accounts)*numberOfCoins)
+	fundedAccountBalances := make([]banktypes.Balance, len(accounts))
+	for i, acc := range accounts {
+		accountCoins := accountsCoins[i*numberOfCoins : (i+1)*numberOfCoins : (i+1)*numberOfCoins]
+		copy(accountCoins, coins)
+		fundedAccountBalances[i] = banktypes.Balance{
+			Address: acc.String(),
+			Coins:   accountCoins,
+		}
+	}
+	return fundedAccountBalances, nil
+}
+
+// createBalancesPerAccount creates balances for the given accounts, funding each
+// account with the set of coins at the same index. It returns an error if the
+// amount of coin sets doesn't match the amount of accounts.
+func createBalancesPerAccount(accounts []sdktypes.AccAddress, coins []sdktypes.Coins) ([]banktypes.Balance, error) {
+	numberOfAccounts := len(accounts)
+	if len(coins) != numberOfAccounts {
+		return nil, fmt.Errorf("expected %d coin sets, one per account, got %d", numberOfAccounts, len(coins))
+	}
+
+	fundedAccountBalances := make([]banktypes.Balance, 0, numberOfAccounts)
+	for i, acc := range accounts {
+		balance := banktypes.Balance{
+			Address: acc.String(),
+			Coins:   coins[i],
+		}
+
+		fundedAccountBalances = append(fundedAccountBalances, balance)
+	}
+	return fundedAccountBalances, nil
+}
+
+// createEvmosApp creates an evmos app backed by the given database and using
+// the given node home path and logger. The app loads the latest version committed to the database.
+// The given addresses are blocked from receiving funds through the bank keeper, in addition
+// to the module accounts and precompiles. The given base app options are applied after the
+// chain ID is set.
+//
+// If an ante handler is given, it replaces the default one of the app before the
+// latest version is loaded, as the BaseApp can't be modified once it is sealed.
+func createEvmosApp(chainID, homePath string, blockedAddrs []sdktypes.AccAddress, db dbm.DB, logger log.Logger, anteHandler sdktypes.AnteHandler, options ...func(*baseapp.BaseApp)) *app.Evmos {
 	// Create evmos app
-	db := dbm.NewMemDB()
-	logger := log.NewNopLogger()
-	loadLatest := true
+	loadLatest := anteHandler == nil
 	skipUpgradeHeights := map[int64]bool{}
-	homePath := app.DefaultNodeHome
+	// NOTE: the Evmos app does not register the crisis module, so the
+	// invariant check period has no effect on the network.
 	invCheckPeriod := uint(5)
 	encodingConfig := encoding.MakeConfig(app.ModuleBasics)
-	appOptions := simutils.NewAppOptionsWithFlagHome(app.DefaultNodeHome)
-	baseAppOptions := []func(*baseapp.BaseApp){baseapp.SetChainID(chainID)}
+	blockedAddrStrs := make([]string, 0, len(blockedAddrs))
+	for _, addr := range blockedAddrs {
+		blockedAddrStrs = append(blockedAddrStrs, addr.String())
+	}
+	appOptions := simutils.AppOptionsMap{
+		flags.FlagHome:            homePath,
+		app.BlockedAddrsAppOption: blockedAddrStrs,
+	}
+	baseAppOptions := append([]func(*baseapp.BaseApp){baseapp.SetChainID(chainID)}, options...)
 
-	return app.NewEvmos(
+	evmosApp := app.NewEvmos(
 		logger,
 		db,
 		nil,
@@ -97,10 +423,43 @@ func createEvmosApp(chainID string) *app.Evmos {
 		appOptions,
 		baseAppOptions...,
 	)
+	if loadLatest {
+		return evmosApp
+	}
+
+	evmosApp.SetAnteHandler(anteHandler)
+	if err := evmosApp.LoadLatestVersion(); err != nil {
+		panic(fmt.Errorf("failed to load latest version: %w", err))
+	}
+	return evmosApp
+}
+
+// validateBlockedAddresses checks that none of the given funded accounts is blocked
+// by the bank keeper of the given app.
+func validateBlockedAddresses(evmosApp *app.Evmos, fundedAccounts []sdktypes.AccAddress) error {
+	for _, addr := range fundedAccounts {
+		if evmosApp.BankKeeper.BlockedAddr(addr) {
+			return fmt.Errorf("blocked address %s cannot be funded at genesis", addr)
+		}
+	}
+	return nil
 }
 
 // createStakingValidator creates a staking validator from the given tm validator and bonded
-func createStakingValidator(val *tmtypes.Validator, bondedAmt sdkmath.Int) (stakingtypes.Validator, error) {
+// amount with zero commission. The unbonding time is set to the given genesis time.
+func createStakingValidator(val *tmtypes.Validator, bondedAmt sdkmath.Int, genesisTime time.Time) (stakingtypes.Validator, error) {
+	commissionRates := stakingtypes.NewCommissionRates(sdktypes.ZeroDec(), sdktypes.ZeroDec(), sdktypes.ZeroDec())
+	return createStakingValidatorWithCommission(val, bondedAmt, commissionRates, genesisTime)
+}
+
+// createStakingValidatorWithCommission creates a staking validator from the given tm validator,
+// bonded amount and commission rates. The unbonding time is set to the given genesis time.
+// It returns an error if the commission rates are invalid, e.g. if the rate exceeds the max rate.
+func createStakingValidatorWithCommission(val *tmtypes.Validator, bondedAmt sdkmath.Int, commissionRates stakingtypes.CommissionRates, genesisTime time.Time) (stakingtypes.Validator, error) {
+	if err := commissionRates.Validate(); err != nil {
+		return stakingtypes.Validator{}, fmt.Errorf("invalid commission rates for validator %s: %w", val.Address, err)
+	}
+
 	pk, err := cryptocodec.FromTmPubKeyInterface(val.PubKey)
 	if err != nil {
 		return stakingtypes.Validator{}, err
@@ -111,7 +470,7 @@ func createStakingValidator(val *tmtypes.Validator, bondedAmt sdkmath.Int) (stak
 		return stakingtypes.Validator{}, err
 	}
 
-	commission := stakingtypes.NewCommission(sdktypes.ZeroDec(), sdktypes.ZeroDec(), sdktypes.ZeroDec())
+	commission := stakingtypes.NewCommission(commissionRates.Rate, commissionRates.MaxRate, commissionRates.MaxChangeRate)
 	validator := stakingtypes.Validator{
 		OperatorAddress:   sdktypes.ValAddress(val.Address).String(),
 		ConsensusPubkey:   pkAny,
@@ -121,20 +480,37 @@ func createStakingValidator(val *tmtypes.Validator, bondedAmt sdkmath.Int) (stak
 		DelegatorShares:   sdktypes.OneDec(),
 		Description:       stakingtypes.Description{},
 		UnbondingHeight:   int64(0),
-		UnbondingTime:     time.Unix(0, 0).UTC(),
+		UnbondingTime:     genesisTime.UTC(),
 		Commission:        commission,
 		MinSelfDelegation: sdktypes.ZeroInt(),
 	}
 	return validator, nil
 }
 
-// createStakingValidators creates staking validators from the given tm validators and bonded
-// amounts
-func createStakingValidators(tmValidators []*tmtypes.Validator, bondedAmt sdkmath.Int) ([]stakingtypes.Validator, error) {
+// createStakingValidators creates staking validators from the given tm validators.
+// The bonded amount of each validator is derived from its voting power.
+//
+// The commission rates are assigned to the validators in order. If fewer commission
+// rates than validators are provided, the last entry is used for the remaining validators.
+// If no commission rates are provided, the validators are created with zero commission.
+func createStakingValidators(tmValidators []*tmtypes.Validator, commissionRates []stakingtypes.CommissionRates, genesisTime time.Time) ([]stakingtypes.Validator, error) {
 	amountOfValidators := len(tmValidators)
 	stakingValidators := make([]stakingtypes.Validator, 0, amountOfValidators)
-	for _, val := range tmValidators {
-		validator, err := createStakingValidator(val, bondedAmt)
+	for i, val := range tmValidators {
+		bondedAmt := sdktypes.TokensFromConsensusPower(val.VotingPower, evmostypes.PowerReduction)
+
+		var (
+			validator stakingtypes.Validator
+			err       error
+		)
+		switch {
+		case len(commissionRates) == 0:
+			validator, err = createStakingValidator(val, bondedAmt, genesisTime)
+		case i < len(commissionRates):
+			validator, err = createStakingValidatorWithCommission(val, bondedAmt, commissionRates[i], genesisTime)
+		default:
+			validator, err = createStakingValidatorWithCommission(val, bondedAmt, commissionRates[len(commissionRates)-1], genesisTime)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -143,84 +519,1356 @@ func createStakingValidators(tmValidators []*tmtypes.Validator, bondedAmt sdkmat
 	return stakingValidators, nil
 }
 
-// createDelegations creates delegations for the given validators and account
-func createDelegations(tmValidators []*tmtypes.Validator, fromAccount sdktypes.AccAddress) []stakingtypes.Delegation {
-	amountOfValidators := len(tmValidators)
-	delegations := make([]stakingtypes.Delegation, 0, amountOfValidators)
-	for _, val := range tmValidators {
-		delegation := stakingtypes.NewDelegation(fromAccount, val.Address.Bytes(), sdktypes.OneDec())
-		delegations = append(delegations, delegation)
+// jailStakingValidators jails the validators at the given indices. Jailed validators
+// are set as unbonded, so they are not part of the active validator set at genesis.
+func jailStakingValidators(validators []stakingtypes.Validator, indices []int) error {
+	for _, i := range indices {
+		if i < 0 || i >= len(validators) {
+			return fmt.Errorf("invalid jailed validator index %d: there are %d validators", i, len(validators))
+		}
+		validators[i].Jailed = true
+		validators[i].Status = stakingtypes.Unbonded
+	}
+	return nil
+}
+
+// createActiveValidatorSet returns the tm validator set containing only the
+// validators that are bonded in the given staking validators.
+func createActiveValidatorSet(valSet *tmtypes.ValidatorSet, validators []stakingtypes.Validator) (*tmtypes.ValidatorSet, error) {
+	activeValidators := make([]*tmtypes.Validator, 0, len(validators))
+	for i, val := range validators {
+		if val.IsBonded() {
+			activeValidators = append(activeValidators, valSet.Validators[i])
+		}
+	}
+
+	if len(activeValidators) == 0 {
+		return nil, fmt.Errorf("at least one validator must not be jailed to produce blocks")
+	}
+	return tmtypes.NewValidatorSet(activeValidators), nil
+}
+
+// createDelegationsMulti creates the given delegations to the given validators. The validators
+// without any of the given delegations get a delegation of one share from the default delegator.
+// The delegator shares of each validator are set to the sum of the shares delegated to it, so
+// that the staking invariants hold.
+// It returns an error if a delegation refers to a validator that is not part of the given
+// validators, if its shares are not positive, or if the same delegation is included twice.
+func createDelegationsMulti(validators []stakingtypes.Validator, defaultDelegator sdktypes.AccAddress, delegations []stakingtypes.Delegation) ([]stakingtypes.Delegation, error) {
+	validatorIndices := make(map[string]int, len(validators))
+	for i, val := range validators {
+		validatorIndices[val.OperatorAddress] = i
+	}
+
+	delegatorShares := make([]sdktypes.Dec, len(validators))
+	for i := range delegatorShares {
+		delegatorShares[i] = sdktypes.ZeroDec()
+	}
+
+	seen := make(map[string]bool, len(delegations))
+	result := make([]stakingtypes.Delegation, 0, len(delegations)+len(validators))
+	for _, delegation := range delegations {
+		i, found := validatorIndices[delegation.ValidatorAddress]
+		if !found {
+			return nil, fmt.Errorf("validator %s of delegation not found in genesis validators", delegation.ValidatorAddress)
+		}
+		if delegation.Shares.IsNil() || !delegation.Shares.IsPositive() {
+			return nil, fmt.Errorf("invalid shares of delegation from %s to %s: must be positive", delegation.DelegatorAddress, delegation.ValidatorAddress)
+		}
+
+		key := delegation.DelegatorAddress + "/" + delegation.ValidatorAddress
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate delegation from %s to %s", delegation.DelegatorAddress, delegation.ValidatorAddress)
+		}
+		seen[key] = true
+
+		delegatorShares[i] = delegatorShares[i].Add(delegation.Shares)
+		result = append(result, delegation)
+	}
+
+	for i, val := range validators {
+		if delegatorShares[i].IsZero() {
+			valAddr, err := sdktypes.ValAddressFromBech32(val.OperatorAddress)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, stakingtypes.NewDelegation(defaultDelegator, valAddr, sdktypes.OneDec()))
+			delegatorShares[i] = sdktypes.OneDec()
+		}
+		validators[i].DelegatorShares = delegatorShares[i]
 	}
-	return delegations
+	return result, nil
 }
 
 // StakingCustomGenesisState defines the staking genesis state
+//
+// NOTE: the staking module of the Cosmos SDK version used by Evmos (v0.47) doesn't include
+// the liquid staking params, i.e. the validator bond factor and the liquid staking caps, nor
+// the validator bond shares, so they cannot be seeded at genesis.
 type StakingCustomGenesisState struct {
-	denom string
+	denom       string
+	genesisTime time.Time
 
-	validators  []stakingtypes.Validator
-	delegations []stakingtypes.Delegation
+	validators           []stakingtypes.Validator
+	delegations          []stakingtypes.Delegation
+	unbondingDelegations []stakingtypes.UnbondingDelegation
+	redelegations        []stakingtypes.Redelegation
+
+	unbondingTime     time.Duration
+	maxValidators     uint32
+	maxEntries        uint32
+	historicalEntries *uint32
+
+	// ibcSeeded is true when the IBC genesis state is provided, so that
+	// the historical info of the genesis validators is required.
+	ibcSeeded bool
 }
 
-// setStakingGenesisState sets the staking genesis state
-func setStakingGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, overwriteParams StakingCustomGenesisState) simapp.GenesisState {
+// setStakingGenesisState sets the staking genesis state. The unbonding time, max
+// validators and max entries params are overwritten when set.
+// It returns an error if the unbonding delegations or redelegations refer to validators
+// that are not part of the genesis validators, if any of their entries completes before
+// the genesis time, if the unbonding time is negative, if the max validators is lower
+// than the amount of genesis validators, or if the historical entries are zero while the
+// IBC genesis state is seeded.
+func setStakingGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, overwriteParams StakingCustomGenesisState) (simapp.GenesisState, error) {
+	validatorAddrs := make(map[string]bool, len(overwriteParams.validators))
+	for _, val := range overwriteParams.validators {
+		validatorAddrs[val.OperatorAddress] = true
+	}
+
+	for _, ubd := range overwriteParams.unbondingDelegations {
+		if !validatorAddrs[ubd.ValidatorAddress] {
+			return nil, fmt.Errorf("validator %s of unbonding delegation not found in genesis validators", ubd.ValidatorAddress)
+		}
+		for _, entry := range ubd.Entries {
+			if !entry.CompletionTime.After(overwriteParams.genesisTime) {
+				return nil, fmt.Errorf("unbonding delegation entry of %s completes at %s, before the genesis time", ubd.DelegatorAddress, entry.CompletionTime)
+			}
+		}
+	}
+
+	for _, red := range overwriteParams.redelegations {
+		if !validatorAddrs[red.ValidatorSrcAddress] {
+			return nil, fmt.Errorf("source validator %s of redelegation not found in genesis validators", red.ValidatorSrcAddress)
+		}
+		if !validatorAddrs[red.ValidatorDstAddress] {
+			return nil, fmt.Errorf("destination validator %s of redelegation not found in genesis validators", red.ValidatorDstAddress)
+		}
+		for _, entry := range red.Entries {
+			if !entry.CompletionTime.After(overwriteParams.genesisTime) {
+				return nil, fmt.Errorf("redelegation entry of %s completes at %s, before the genesis time", red.DelegatorAddress, entry.CompletionTime)
+			}
+		}
+	}
+
 	// Set staking params
 	stakingParams := stakingtypes.DefaultParams()
 	stakingParams.BondDenom = overwriteParams.denom
+	if overwriteParams.unbondingTime < 0 {
+		return nil, fmt.Errorf("invalid unbonding time %s: must be positive", overwriteParams.unbondingTime)
+	}
+	if overwriteParams.unbondingTime > 0 {
+		stakingParams.UnbondingTime = overwriteParams.unbondingTime
+	}
+	if overwriteParams.maxValidators > 0 {
+		stakingParams.MaxValidators = overwriteParams.maxValidators
+	}
+	if overwriteParams.maxEntries > 0 {
+		stakingParams.MaxEntries = overwriteParams.maxEntries
+	}
+	if overwriteParams.historicalEntries != nil {
+		stakingParams.HistoricalEntries = *overwriteParams.historicalEntries
+	}
+	if overwriteParams.ibcSeeded && stakingParams.HistoricalEntries == 0 {
+		return nil, fmt.Errorf("historical entries must be positive when the IBC genesis state is seeded")
+	}
+	if int(stakingParams.MaxValidators) < len(overwriteParams.validators) {
+		return nil, fmt.Errorf("max validators %d is lower than the %d genesis validators", stakingParams.MaxValidators, len(overwriteParams.validators))
+	}
+	if err := stakingParams.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid staking params: %w", err)
+	}
 
 	stakingGenesis := stakingtypes.NewGenesisState(stakingParams, overwriteParams.validators, overwriteParams.delegations)
+	stakingGenesis.UnbondingDelegations = overwriteParams.unbondingDelegations
+	stakingGenesis.Redelegations = overwriteParams.redelegations
 	genesisState[stakingtypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(stakingGenesis)
-	return genesisState
+	return genesisState, nil
 }
 
-// setAuthGenesisState sets the auth genesis state
-func setAuthGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, genAccounts []authtypes.GenesisAccount) simapp.GenesisState {
-	authGenesis := authtypes.NewGenesisState(authtypes.DefaultParams(), genAccounts)
-	genesisState[authtypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(authGenesis)
-	return genesisState
+// setGenesisHistoricalInfo stores the historical info of the given genesis header with the
+// current validators, since the staking module only tracks the historical info of the
+// following blocks. The IBC clients of the counterparty chains verify the headers against it.
+// Nothing is stored if the historical entries of the staking params are zero.
+func setGenesisHistoricalInfo(ctx sdktypes.Context, evmosApp *app.Evmos, header tmproto.Header) {
+	if evmosApp.StakingKeeper.HistoricalEntries(ctx) == 0 {
+		return
+	}
+	validators := evmosApp.StakingKeeper.GetLastValidators(ctx)
+	historicalInfo := stakingtypes.NewHistoricalInfo(header, stakingtypes.Validators(validators), evmosApp.StakingKeeper.PowerReduction(ctx))
+	evmosApp.StakingKeeper.SetHistoricalInfo(ctx, header.Height, &historicalInfo)
 }
 
-// setInflationGenesisState sets the inflation genesis state
-func setInflationGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState) simapp.GenesisState {
-	inflationParams := infltypes.DefaultParams()
-	inflationParams.EnableInflation = false
+// setEvidenceGenesisState sets the evidence genesis state. If no custom evidence
+// genesis is provided, the default empty genesis state is used.
+// It returns an error if any equivocation refers to a consensus address that is not
+// part of the genesis validators, or if its infraction height is after the given genesis height.
+func setEvidenceGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, genesisHeight int64) (simapp.GenesisState, error) {
+	evidenceGenesis, err := getCustomGenesisState(customGenesis, evidencetypes.ModuleName, evidencetypes.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := evidenceGenesis.UnpackInterfaces(evmosApp.InterfaceRegistry()); err != nil {
+		return nil, err
+	}
+
+	var stakingGenesis stakingtypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[stakingtypes.ModuleName], &stakingGenesis)
+
+	consAddrs := make(map[string]bool, len(stakingGenesis.Validators))
+	for _, val := range stakingGenesis.Validators {
+		consAddr, err := val.GetConsAddr()
+		if err != nil {
+			return nil, err
+		}
+		consAddrs[consAddr.String()] = true
+	}
+
+	for _, evidenceAny := range evidenceGenesis.Evidence {
+		equivocation, ok := evidenceAny.GetCachedValue().(*evidencetypes.Equivocation)
+		if !ok {
+			continue
+		}
+		if !consAddrs[equivocation.ConsensusAddress] {
+			return nil, fmt.Errorf("consensus address %s of equivocation not found in genesis validators", equivocation.ConsensusAddress)
+		}
+		if equivocation.Height > genesisHeight {
+			return nil, fmt.Errorf("equivocation height %d is after the genesis height %d", equivocation.Height, genesisHeight)
+		}
+	}
 
-	inflationGenesis := infltypes.NewGenesisState(inflationParams, uint64(0), epochstypes.DayEpochID, 365, 0)
-	genesisState[infltypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(&inflationGenesis)
-	return genesisState
+	genesisState[evidencetypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(evidenceGenesis)
+	return genesisState, nil
 }
 
-type BankCustomGenesisState struct {
-	totalSupply sdktypes.Coins
-	balances    []banktypes.Balance
+// getCustomGenesisState returns the custom genesis state for the given module
+// if it was provided. Otherwise, it returns the given default genesis state.
+func getCustomGenesisState[T any](customGenesis CustomGenesisState, moduleName string, defaultGenesis T) (T, error) {
+	customModuleGenesis, found := customGenesis[moduleName]
+	if !found {
+		return defaultGenesis, nil
+	}
+
+	moduleGenesis, ok := customModuleGenesis.(T)
+	if !ok {
+		return defaultGenesis, fmt.Errorf("invalid type %T for %s module genesis state", customModuleGenesis, moduleName)
+	}
+	return moduleGenesis, nil
 }
 
-// setBankGenesisState sets the bank genesis state
-func setBankGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, overwriteParams BankCustomGenesisState) simapp.GenesisState {
-	bankGenesis := banktypes.NewGenesisState(
-		banktypes.DefaultGenesisState().Params,
-		overwriteParams.balances,
-		overwriteParams.totalSupply,
-		[]banktypes.Metadata{},
-		[]banktypes.SendEnabled{},
-	)
-	genesisState[banktypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(bankGenesis)
-	return genesisState
+// SlashingCustomGenesisState defines the downtime params set on top of the slashing genesis state
+type SlashingCustomGenesisState struct {
+	signedBlocksWindow int64
+	minSignedPerWindow *sdktypes.Dec
 }
 
-// calculateTotalSupply calculates the total supply from the given balances
-func calculateTotalSupply(fundedAccountsBalances []banktypes.Balance) sdktypes.Coins {
-	totalSupply := sdktypes.NewCoins()
-	for _, balance := range fundedAccountsBalances {
-		totalSupply = totalSupply.Add(balance.Coins...)
+// setSlashingGenesisState sets the slashing genesis state. If no custom
+// slashing genesis is provided, the default genesis state is used. If the given
+// params have a signed blocks window, it overwrites the downtime params of the genesis.
+// It returns an error if the signed blocks window or the min signed per window
+// are not positive, or if the min signed per window is greater than one.
+func setSlashingGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, overwriteParams SlashingCustomGenesisState) (simapp.GenesisState, error) {
+	slashingGenesis, err := getCustomGenesisState(customGenesis, slashingtypes.ModuleName, slashingtypes.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+
+	if overwriteParams.minSignedPerWindow != nil {
+		if overwriteParams.signedBlocksWindow <= 0 {
+			return nil, fmt.Errorf("invalid signed blocks window %d: must be positive", overwriteParams.signedBlocksWindow)
+		}
+		minSigned := *overwriteParams.minSignedPerWindow
+		if !minSigned.IsPositive() || minSigned.GT(sdktypes.OneDec()) {
+			return nil, fmt.Errorf("invalid min signed per window %s: must be positive and at most one", minSigned)
+		}
+		slashingGenesis.Params.SignedBlocksWindow = overwriteParams.signedBlocksWindow
+		slashingGenesis.Params.MinSignedPerWindow = minSigned
 	}
-	return totalSupply
+
+	genesisState[slashingtypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(slashingGenesis)
+	return genesisState, nil
 }
 
-// addBondedModuleAccountToFundedBalances adds bonded amount to bonded pool module account and include it on funded accounts
-func addBondedModuleAccountToFundedBalances(fundedAccountsBalances []banktypes.Balance, totalBonded sdktypes.Coin) []banktypes.Balance {
-	return append(fundedAccountsBalances, banktypes.Balance{
-		Address: authtypes.NewModuleAddress(stakingtypes.BondedPoolName).String(),
-		Coins:   sdktypes.Coins{totalBonded},
+// DistributionCustomGenesisState defines the community pool and the delegator withdraw
+// addresses set on top of the distribution genesis state
+type DistributionCustomGenesisState struct {
+	communityPool     sdktypes.DecCoins
+	withdrawAddresses []distrtypes.DelegatorWithdrawInfo
+	fundedAccounts    []sdktypes.AccAddress
+}
+
+// setDistributionGenesisState sets the distribution genesis state. If no custom
+// distribution genesis is provided, the default genesis state is used. If the given
+// params have a community pool, it overwrites the one of the genesis. The given
+// withdraw addresses are added to the ones of the genesis.
+// It returns an error if the community pool is invalid, if any of the outstanding
+// rewards refers to a validator that is not part of the staking genesis state, or if
+// any of the given delegator or withdraw addresses is not a funded account.
+func setDistributionGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, overwriteParams DistributionCustomGenesisState) (simapp.GenesisState, error) {
+	distrGenesis, err := getCustomGenesisState(customGenesis, distrtypes.ModuleName, distrtypes.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+	if overwriteParams.communityPool != nil {
+		if err := overwriteParams.communityPool.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid community pool: %w", err)
+		}
+		distrGenesis.FeePool.CommunityPool = overwriteParams.communityPool
+	}
+
+	var stakingGenesis stakingtypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[stakingtypes.ModuleName], &stakingGenesis)
+
+	genesisValidators := make(map[string]bool, len(stakingGenesis.Validators))
+	for _, val := range stakingGenesis.Validators {
+		genesisValidators[val.OperatorAddress] = true
+	}
+
+	for _, rewards := range distrGenesis.OutstandingRewards {
+		if !genesisValidators[rewards.ValidatorAddress] {
+			return nil, fmt.Errorf("outstanding rewards validator %s not found in staking genesis", rewards.ValidatorAddress)
+		}
+	}
+
+	withdrawAddresses, err := createDelegatorWithdrawInfos(distrGenesis.DelegatorWithdrawInfos, overwriteParams.withdrawAddresses, overwriteParams.fundedAccounts)
+	if err != nil {
+		return nil, err
+	}
+	distrGenesis.DelegatorWithdrawInfos = withdrawAddresses
+
+	genesisState[distrtypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(distrGenesis)
+	return genesisState, nil
+}
+
+// createDelegatorWithdrawInfos returns the given genesis withdraw infos together with the
+// given withdraw addresses. It returns an error if a delegator has more than one withdraw
+// address, or if any of the delegator or withdraw addresses is not a funded account.
+func createDelegatorWithdrawInfos(genesisInfos, withdrawAddresses []distrtypes.DelegatorWithdrawInfo, fundedAccounts []sdktypes.AccAddress) ([]distrtypes.DelegatorWithdrawInfo, error) {
+	funded := make(map[string]bool, len(fundedAccounts))
+	for _, acc := range fundedAccounts {
+		funded[acc.String()] = true
+	}
+
+	delegators := make(map[string]bool, len(genesisInfos)+len(withdrawAddresses))
+	for _, info := range genesisInfos {
+		delegators[info.DelegatorAddress] = true
+	}
+
+	withdrawInfos := append([]distrtypes.DelegatorWithdrawInfo{}, genesisInfos...)
+	for _, info := range withdrawAddresses {
+		if !funded[info.DelegatorAddress] {
+			return nil, fmt.Errorf("delegator %s of the withdraw address is not a funded account", info.DelegatorAddress)
+		}
+		if !funded[info.WithdrawAddress] {
+			return nil, fmt.Errorf("withdraw address %s of delegator %s is not a funded account", info.WithdrawAddress, info.DelegatorAddress)
+		}
+		if delegators[info.DelegatorAddress] {
+			return nil, fmt.Errorf("duplicate withdraw address for delegator %s", info.DelegatorAddress)
+		}
+		delegators[info.DelegatorAddress] = true
+		withdrawInfos = append(withdrawInfos, info)
+	}
+	return withdrawInfos, nil
+}
+
+// addCustomPrecompiles registers the given precompiles as EVM extensions, so that they
+// are available on the EVM keeper and active on the EVM params. The precompiles are
+// added in ascending order of their addresses. When the app is loaded from a committed
+// state where the precompiles are already active, they are only made available on the
+// EVM keeper.
+// It returns an error if a precompile address doesn't match the contract address or
+// if the precompile is already registered.
+func addCustomPrecompiles(ctx sdktypes.Context, evmosApp *app.Evmos, precompiles map[common.Address]vm.PrecompiledContract) error {
+	addresses := make([]common.Address, 0, len(precompiles))
+	for addr, precompile := range precompiles {
+		if precompile.Address() != addr {
+			return fmt.Errorf("precompile address mismatch: expected %s, got %s", addr, precompile.Address())
+		}
+		addresses = append(addresses, addr)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
 	})
+
+	activePrecompiles := make(map[string]bool)
+	for _, addr := range evmosApp.EvmKeeper.GetParams(ctx).ActivePrecompiles {
+		activePrecompiles[addr] = true
+	}
+
+	for _, addr := range addresses {
+		extensionCtx := ctx
+		if activePrecompiles[addr.Hex()] {
+			// NOTE: the precompile is added on a discarded cache context where it
+			// is not active, so that only the EVM keeper's available precompiles change.
+			extensionCtx, _ = ctx.CacheContext()
+			params := evmosApp.EvmKeeper.GetParams(extensionCtx)
+			inactivePrecompiles := make([]string, 0, len(params.ActivePrecompiles))
+			for _, active := range params.ActivePrecompiles {
+				if active != addr.Hex() {
+					inactivePrecompiles = append(inactivePrecompiles, active)
+				}
+			}
+			params.ActivePrecompiles = inactivePrecompiles
+			if err := evmosApp.EvmKeeper.SetParams(extensionCtx, params); err != nil {
+				return err
+			}
+		}
+
+		if err := evmosApp.EvmKeeper.AddEVMExtensions(extensionCtx, precompiles[addr]); err != nil {
+			return fmt.Errorf("failed to add custom precompile %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// setDistributionValidatorRecords sets the validators' distribution records
+// (outstanding rewards, current rewards and accumulated commission) included in
+// the given distribution genesis state.
+func setDistributionValidatorRecords(ctx sdktypes.Context, evmosApp *app.Evmos, distrGenesis *distrtypes.GenesisState) error {
+	for _, record := range distrGenesis.OutstandingRewards {
+		valAddr, err := sdktypes.ValAddressFromBech32(record.ValidatorAddress)
+		if err != nil {
+			return err
+		}
+		evmosApp.DistrKeeper.SetValidatorOutstandingRewards(ctx, valAddr, distrtypes.ValidatorOutstandingRewards{Rewards: record.OutstandingRewards})
+	}
+
+	for _, record := range distrGenesis.ValidatorCurrentRewards {
+		valAddr, err := sdktypes.ValAddressFromBech32(record.ValidatorAddress)
+		if err != nil {
+			return err
+		}
+		evmosApp.DistrKeeper.SetValidatorCurrentRewards(ctx, valAddr, record.Rewards)
+	}
+
+	for _, record := range distrGenesis.ValidatorAccumulatedCommissions {
+		valAddr, err := sdktypes.ValAddressFromBech32(record.ValidatorAddress)
+		if err != nil {
+			return err
+		}
+		evmosApp.DistrKeeper.SetValidatorAccumulatedCommission(ctx, valAddr, record.Accumulated)
+	}
+
+	return nil
+}
+
+// GovCustomGenesisState defines the params and proposals set on top of the gov genesis state
+type GovCustomGenesisState struct {
+	params                 *govv1types.Params
+	depositPeriodProposals []depositPeriodProposal
+	genesisTime            time.Time
+}
+
+// depositPeriodProposal defines a proposal submitted at genesis that remains in the
+// deposit period with a partial deposit of its depositor
+type depositPeriodProposal struct {
+	depositor     sdktypes.AccAddress
+	deposit       sdktypes.Coins
+	depositPeriod time.Duration
+}
+
+// setGovGenesisState sets the gov genesis state. If no custom gov genesis is
+// provided, the default genesis state is used. If the given params have gov params,
+// they overwrite the ones of the genesis.
+// The given deposit period proposals are added after the proposals of the genesis.
+// It returns an error if the voting or deposit periods of the given gov params are not
+// positive, if their min deposit denom is not the staking bond denom, if any of the
+// votes refers to a voter that is not a genesis account or if any of the deposit
+// period proposals is invalid.
+func setGovGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, overwriteParams GovCustomGenesisState) (simapp.GenesisState, error) {
+	govGenesis, err := getCustomGenesisState(customGenesis, govtypes.ModuleName, govv1types.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+	if overwriteParams.params != nil {
+		if err := validateGovParams(overwriteParams.params, getBondDenom(evmosApp, genesisState)); err != nil {
+			return nil, err
+		}
+		govGenesis.Params = overwriteParams.params
+	}
+
+	genesisAccounts, err := getGenesisAccountAddresses(evmosApp, genesisState)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vote := range govGenesis.Votes {
+		if !genesisAccounts[vote.Voter] {
+			return nil, fmt.Errorf("voter %s of proposal %d not found in genesis accounts", vote.Voter, vote.ProposalId)
+		}
+	}
+
+	bondDenom := getBondDenom(evmosApp, genesisState)
+	for _, proposal := range overwriteParams.depositPeriodProposals {
+		if !genesisAccounts[proposal.depositor.String()] {
+			return nil, fmt.Errorf("depositor %s of deposit period proposal not found in genesis accounts", proposal.depositor)
+		}
+		if err := addDepositPeriodProposal(govGenesis, proposal, bondDenom, overwriteParams.genesisTime); err != nil {
+			return nil, err
+		}
+	}
+
+	genesisState[govtypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(govGenesis)
+	return genesisState, nil
+}
+
+// addDepositPeriodProposal adds a proposal submitted at the given genesis time to the
+// given gov genesis, together with the partial deposit of its depositor. The deposit
+// period of the proposal ends after the given deposit period, so a zero deposit period
+// makes the proposal expire on the EndBlock of the first block.
+// It returns an error if the deposit is not a positive amount of the bond denom, if it
+// reaches the min deposit or if the deposit period is negative or longer than the max
+// deposit period of the gov params.
+func addDepositPeriodProposal(govGenesis *govv1types.GenesisState, proposal depositPeriodProposal, bondDenom string, genesisTime time.Time) error {
+	if !proposal.deposit.IsValid() || proposal.deposit.IsZero() {
+		return fmt.Errorf("invalid deposit %s of deposit period proposal: must be positive", proposal.deposit)
+	}
+	for _, coin := range proposal.deposit {
+		if coin.Denom != bondDenom {
+			return fmt.Errorf("deposit denom %s of deposit period proposal doesn't match the bond denom %s", coin.Denom, bondDenom)
+		}
+	}
+
+	params := govGenesis.Params
+	if params == nil || params.MaxDepositPeriod == nil {
+		return fmt.Errorf("gov max deposit period is not set")
+	}
+	if proposal.deposit.IsAllGTE(params.MinDeposit) {
+		return fmt.Errorf("deposit %s of deposit period proposal reaches the min deposit %s", proposal.deposit, sdktypes.NewCoins(params.MinDeposit...))
+	}
+	if proposal.depositPeriod < 0 || proposal.depositPeriod > *params.MaxDepositPeriod {
+		return fmt.Errorf("invalid deposit period %s of deposit period proposal: must be between 0 and the max deposit period %s", proposal.depositPeriod, *params.MaxDepositPeriod)
+	}
+
+	proposalID := govGenesis.StartingProposalId
+	for _, p := range govGenesis.Proposals {
+		if p.Id >= proposalID {
+			proposalID = p.Id + 1
+		}
+	}
+
+	submitTime := genesisTime.UTC()
+	depositEndTime := submitTime.Add(proposal.depositPeriod)
+	tallyResult := govv1types.EmptyTallyResult()
+	govGenesis.Proposals = append(govGenesis.Proposals, &govv1types.Proposal{
+		Id:               proposalID,
+		Status:           govv1types.StatusDepositPeriod,
+		FinalTallyResult: &tallyResult,
+		SubmitTime:       &submitTime,
+		DepositEndTime:   &depositEndTime,
+		TotalDeposit:     proposal.deposit,
+		Title:            fmt.Sprintf("Deposit period proposal %d", proposalID),
+		Summary:          "Proposal in the deposit period created at genesis",
+		Proposer:         proposal.depositor.String(),
+	})
+
+	deposit := govv1types.NewDeposit(proposalID, proposal.depositor, proposal.deposit)
+	govGenesis.Deposits = append(govGenesis.Deposits, &deposit)
+	govGenesis.StartingProposalId = proposalID + 1
+	return nil
+}
+
+// validateGovParams checks that the voting and deposit periods of the given gov params
+// are positive and that the min deposit is in the given bond denom.
+func validateGovParams(params *govv1types.Params, bondDenom string) error {
+	if params == nil {
+		return fmt.Errorf("gov params cannot be nil")
+	}
+	if params.VotingPeriod == nil || *params.VotingPeriod <= 0 {
+		return fmt.Errorf("invalid gov voting period %v: must be positive", params.VotingPeriod)
+	}
+	if params.MaxDepositPeriod == nil || *params.MaxDepositPeriod <= 0 {
+		return fmt.Errorf("invalid gov max deposit period %v: must be positive", params.MaxDepositPeriod)
+	}
+	for _, coin := range params.MinDeposit {
+		if coin.Denom != bondDenom {
+			return fmt.Errorf("gov min deposit denom %s doesn't match the bond denom %s", coin.Denom, bondDenom)
+		}
+	}
+	return params.ValidateBasic()
+}
+
+// getGenesisAccounts returns the accounts included in the auth genesis state.
+func getGenesisAccounts(evmosApp *app.Evmos, genesisState simapp.GenesisState) (authtypes.GenesisAccounts, error) {
+	var authGenesis authtypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[authtypes.ModuleName], &authGenesis)
+
+	return authtypes.UnpackAccounts(authGenesis.Accounts)
+}
+
+// getGenesisAccountAddresses returns the set of addresses of the accounts included
+// in the auth genesis state.
+func getGenesisAccountAddresses(evmosApp *app.Evmos, genesisState simapp.GenesisState) (map[string]bool, error) {
+	genAccounts, err := getGenesisAccounts(evmosApp, genesisState)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make(map[string]bool, len(genAccounts))
+	for _, acc := range genAccounts {
+		addresses[acc.GetAddress().String()] = true
+	}
+	return addresses, nil
+}
+
+// validateLockedVestingBalances checks that the clawback vesting accounts of the auth
+// genesis state that have no vested coins at the genesis time, i.e. the accounts before
+// their vesting cliff, have no spendable balance in the bank genesis state.
+func validateLockedVestingBalances(evmosApp *app.Evmos, genesisState simapp.GenesisState, genesisTime time.Time) error {
+	genAccounts, err := getGenesisAccounts(evmosApp, genesisState)
+	if err != nil {
+		return err
+	}
+
+	var bankGenesis banktypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[banktypes.ModuleName], &bankGenesis)
+	balances := make(map[string]sdktypes.Coins, len(bankGenesis.Balances))
+	for _, balance := range bankGenesis.Balances {
+		balances[balance.Address] = balance.Coins
+	}
+
+	for _, acc := range genAccounts {
+		vestingAcc, ok := acc.(*vestingtypes.ClawbackVestingAccount)
+		if !ok || !vestingAcc.GetVestedOnly(genesisTime).IsZero() {
+			continue
+		}
+
+		spendable, hasNeg := balances[vestingAcc.Address].SafeSub(vestingAcc.LockedCoins(genesisTime)...)
+		if !hasNeg && !spendable.IsZero() {
+			return fmt.Errorf("vesting account %s has spendable balance %s before its vesting cliff", vestingAcc.Address, spendable)
+		}
+	}
+	return nil
+}
+
+// getFundedAddresses returns the set of addresses holding a balance in the
+// bank genesis state.
+func getFundedAddresses(evmosApp *app.Evmos, genesisState simapp.GenesisState) map[string]bool {
+	var bankGenesis banktypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[banktypes.ModuleName], &bankGenesis)
+
+	addresses := make(map[string]bool, len(bankGenesis.Balances))
+	for _, balance := range bankGenesis.Balances {
+		addresses[balance.Address] = true
+	}
+	return addresses
+}
+
+// getBondDenom returns the bond denom of the staking genesis state.
+func getBondDenom(evmosApp *app.Evmos, genesisState simapp.GenesisState) string {
+	var stakingGenesis stakingtypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[stakingtypes.ModuleName], &stakingGenesis)
+	return stakingGenesis.Params.BondDenom
+}
+
+// setFeeGrantGenesisState sets the feegrant genesis state. If no custom feegrant
+// genesis is provided, the default empty genesis state is used.
+// It returns an error if the granter or grantee of any allowance is not a funded
+// account, or if a basic allowance spend limit is negative or not in the bond denom.
+func setFeeGrantGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState) (simapp.GenesisState, error) {
+	feeGrantGenesis, err := getCustomGenesisState(customGenesis, feegrant.ModuleName, feegrant.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := feeGrantGenesis.UnpackInterfaces(evmosApp.InterfaceRegistry()); err != nil {
+		return nil, err
+	}
+
+	fundedAddresses := getFundedAddresses(evmosApp, genesisState)
+	bondDenom := getBondDenom(evmosApp, genesisState)
+
+	for _, grant := range feeGrantGenesis.Allowances {
+		if !fundedAddresses[grant.Granter] {
+			return nil, fmt.Errorf("granter %s of fee allowance not found in funded accounts", grant.Granter)
+		}
+		if !fundedAddresses[grant.Grantee] {
+			return nil, fmt.Errorf("grantee %s of fee allowance not found in funded accounts", grant.Grantee)
+		}
+
+		allowance, err := grant.GetGrant()
+		if err != nil {
+			return nil, err
+		}
+
+		basicAllowance, ok := allowance.(*feegrant.BasicAllowance)
+		if !ok {
+			continue
+		}
+		if basicAllowance.SpendLimit.IsAnyNegative() {
+			return nil, fmt.Errorf("invalid spend limit %s for grantee %s: must be non-negative", basicAllowance.SpendLimit, grant.Grantee)
+		}
+		for _, coin := range basicAllowance.SpendLimit {
+			if coin.Denom != bondDenom {
+				return nil, fmt.Errorf("invalid spend limit denom %s for grantee %s: expected %s", coin.Denom, grant.Grantee, bondDenom)
+			}
+		}
+	}
+
+	genesisState[feegrant.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(feeGrantGenesis)
+	return genesisState, nil
+}
+
+// setAuthzGenesisState sets the authz genesis state. If no custom authz genesis
+// is provided, the default empty genesis state is used.
+// It returns an error if the granter or grantee of any authorization is not a
+// funded account, or if any authorization expires before the given genesis time.
+func setAuthzGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, genesisTime time.Time) (simapp.GenesisState, error) {
+	authzGenesis, err := getCustomGenesisState(customGenesis, authz.ModuleName, authz.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+
+	fundedAddresses := getFundedAddresses(evmosApp, genesisState)
+
+	for _, grant := range authzGenesis.Authorization {
+		if !fundedAddresses[grant.Granter] {
+			return nil, fmt.Errorf("granter %s of authorization not found in funded accounts", grant.Granter)
+		}
+		if !fundedAddresses[grant.Grantee] {
+			return nil, fmt.Errorf("grantee %s of authorization not found in funded accounts", grant.Grantee)
+		}
+		if grant.Expiration != nil && !grant.Expiration.After(genesisTime) {
+			return nil, fmt.Errorf("authorization of grantee %s expires at %s, before the genesis time", grant.Grantee, grant.Expiration)
+		}
+	}
+
+	genesisState[authz.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(authzGenesis)
+	return genesisState, nil
+}
+
+// GenesisContract defines the code, storage and nonce of a contract that is
+// included in the EVM genesis state.
+type GenesisContract struct {
+	Code    []byte
+	Storage map[common.Hash]common.Hash
+	// Nonce is the amount of contracts created by the contract, which
+	// determines the address of its next CREATE deployment.
+	Nonce uint64
+}
+
+// sortedContractAddresses returns the addresses of the given contracts sorted
+// in ascending order, so that the genesis state is deterministic.
+func sortedContractAddresses(contracts map[common.Address]GenesisContract) []common.Address {
+	addresses := make([]common.Address, 0, len(contracts))
+	for addr := range contracts {
+		addresses = append(addresses, addr)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	return addresses
+}
+
+// createContractGenesisAccounts returns the EthAccount genesis accounts for the given
+// contracts, with the code hash of each contract's code and the contract's nonce.
+func createContractGenesisAccounts(contracts map[common.Address]GenesisContract) ([]authtypes.GenesisAccount, error) {
+	addresses := sortedContractAddresses(contracts)
+	accounts := make([]sdktypes.AccAddress, 0, len(addresses))
+	codeHashes := make([]common.Hash, 0, len(addresses))
+	nonces := make([]uint64, 0, len(addresses))
+	for _, addr := range addresses {
+		accounts = append(accounts, addr.Bytes())
+		codeHashes = append(codeHashes, crypto.Keccak256Hash(contracts[addr].Code))
+		nonces = append(nonces, contracts[addr].Nonce)
+	}
+	return createEthGenesisAccounts(accounts, codeHashes, nonces)
+}
+
+// createEVMGenesisAccounts returns the EVM genesis accounts containing the code
+// and storage of the given contracts.
+func createEVMGenesisAccounts(contracts map[common.Address]GenesisContract) []evmtypes.GenesisAccount {
+	addresses := sortedContractAddresses(contracts)
+	evmAccounts := make([]evmtypes.GenesisAccount, 0, len(addresses))
+	for _, addr := range addresses {
+		contract := contracts[addr]
+
+		storage := make(evmtypes.Storage, 0, len(contract.Storage))
+		for key, value := range contract.Storage {
+			storage = append(storage, evmtypes.NewState(key, value))
+		}
+		sort.Slice(storage, func(i, j int) bool {
+			return storage[i].Key < storage[j].Key
+		})
+
+		evmAccounts = append(evmAccounts, evmtypes.GenesisAccount{
+			Address: addr.Hex(),
+			Code:    common.Bytes2Hex(contract.Code),
+			Storage: storage,
+		})
+	}
+	return evmAccounts
+}
+
+// EVMCustomGenesisState defines the customizations applied on top of the EVM genesis state
+type EVMCustomGenesisState struct {
+	contracts       map[common.Address]GenesisContract
+	disabled        bool
+	paramsModifiers []func(*evmtypes.Params)
+}
+
+// setEVMGenesisState sets the EVM genesis state. If no custom EVM genesis is
+// provided, the default genesis state is used. The contracts from the given
+// params are appended to the genesis accounts and the params modifiers are applied
+// in order. If the EVM is disabled, calls and contract creations are not allowed and
+// no precompile is activated.
+// It returns an error if the modified params are invalid, or if any of the EVM genesis
+// accounts doesn't have a matching EthAccount in the auth genesis state.
+func setEVMGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, overwriteParams EVMCustomGenesisState) (simapp.GenesisState, error) {
+	evmGenesis, err := getCustomGenesisState(customGenesis, evmtypes.ModuleName, evmtypes.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+	evmGenesis.Accounts = append(evmGenesis.Accounts, createEVMGenesisAccounts(overwriteParams.contracts)...)
+	for _, modifier := range overwriteParams.paramsModifiers {
+		modifier(&evmGenesis.Params)
+	}
+	if overwriteParams.disabled {
+		evmGenesis.Params.EnableCall = false
+		evmGenesis.Params.EnableCreate = false
+		evmGenesis.Params.ActivePrecompiles = nil
+	}
+	if err := evmGenesis.Params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid EVM params: %w", err)
+	}
+
+	genAccounts, err := getGenesisAccounts(evmosApp, genesisState)
+	if err != nil {
+		return nil, err
+	}
+
+	ethAccounts := make(map[common.Address]evmostypes.EthAccountI, len(genAccounts))
+	for _, acc := range genAccounts {
+		if ethAcc, ok := acc.(evmostypes.EthAccountI); ok {
+			ethAccounts[ethAcc.EthAddress()] = ethAcc
+		}
+	}
+
+	for _, account := range evmGenesis.Accounts {
+		ethAcc, found := ethAccounts[common.HexToAddress(account.Address)]
+		if !found {
+			return nil, fmt.Errorf("EVM genesis account %s has no matching EthAccount in auth genesis", account.Address)
+		}
+
+		codeHash := crypto.Keccak256Hash(common.Hex2Bytes(account.Code))
+		if len(account.Code) != 0 && ethAcc.GetCodeHash() != codeHash {
+			return nil, fmt.Errorf("code hash mismatch for EVM genesis account %s: expected %s, got %s", account.Address, codeHash, ethAcc.GetCodeHash())
+		}
+	}
+
+	genesisState[evmtypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(evmGenesis)
+	return genesisState, nil
+}
+
+// validateEVMDenomMetadata checks that the bank genesis has the metadata of the EVM
+// denom when it differs from the default one.
+func validateEVMDenomMetadata(evmosApp *app.Evmos, genesisState simapp.GenesisState) error {
+	evmGenesis := &evmtypes.GenesisState{}
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[evmtypes.ModuleName], evmGenesis)
+	if evmGenesis.Params.EvmDenom == evmtypes.DefaultEVMDenom {
+		return nil
+	}
+
+	bankGenesis := &banktypes.GenesisState{}
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[banktypes.ModuleName], bankGenesis)
+	for _, metadata := range bankGenesis.DenomMetadata {
+		if metadata.Base == evmGenesis.Params.EvmDenom {
+			return nil
+		}
+	}
+	return fmt.Errorf("EVM denom %s has no bank metadata", evmGenesis.Params.EvmDenom)
+}
+
+// FeeMarketCustomGenesisState defines the gas wanted on the block before the genesis,
+// which is used to calculate the base fee of the first block.
+//
+// NOTE: the gas wanted of each transaction is kept in the transient store
+// and is not part of the genesis state, so it cannot be seeded.
+type FeeMarketCustomGenesisState struct {
+	blockGasWanted uint64
+	maxBlockGas    int64
+}
+
+// setFeeMarketGenesisState sets the feemarket genesis state. If no custom feemarket
+// genesis is provided, the default genesis state is used. If the given params have a
+// block gas wanted, it overwrites the one of the genesis.
+// It returns an error if the min gas price is negative, if the params are invalid, e.g.
+// with a zero elasticity multiplier or base fee change denominator, or if the block gas
+// wanted exceeds the max block gas.
+func setFeeMarketGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, overwriteParams FeeMarketCustomGenesisState) (simapp.GenesisState, error) {
+	feeMarketGenesis, err := getCustomGenesisState(customGenesis, feemarkettypes.ModuleName, feemarkettypes.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+	if overwriteParams.blockGasWanted > 0 {
+		feeMarketGenesis.BlockGas = overwriteParams.blockGasWanted
+	}
+
+	if feeMarketGenesis.Params.MinGasPrice.IsNegative() {
+		return nil, fmt.Errorf("invalid min gas price %s: must be non-negative", feeMarketGenesis.Params.MinGasPrice)
+	}
+	if err := feeMarketGenesis.Params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid feemarket params: %w", err)
+	}
+	// NOTE: the base fee calculation divides the max block gas by the elasticity multiplier
+	if feeMarketGenesis.Params.ElasticityMultiplier == 0 {
+		return nil, fmt.Errorf("invalid feemarket params: elasticity multiplier cannot be 0")
+	}
+	// NOTE: a max block gas of -1 means that the block gas is unlimited
+	if overwriteParams.maxBlockGas > -1 && feeMarketGenesis.BlockGas > uint64(overwriteParams.maxBlockGas) {
+		return nil, fmt.Errorf("block gas wanted %d exceeds the max block gas %d", feeMarketGenesis.BlockGas, overwriteParams.maxBlockGas)
+	}
+
+	genesisState[feemarkettypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(feeMarketGenesis)
+	return genesisState, nil
+}
+
+// Erc20CustomGenesisState defines the token pairs registered on top of the erc20 genesis state
+type Erc20CustomGenesisState struct {
+	tokenPairs []erc20types.TokenPair
+}
+
+// setErc20GenesisState sets the erc20 genesis state. If no custom erc20 genesis
+// is provided, the default genesis state is used. The token pairs from the given
+// params are appended to the genesis token pairs.
+// It returns an error if any of the token pairs' denominations has neither
+// metadata nor supply in the bank genesis state.
+func setErc20GenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, overwriteParams Erc20CustomGenesisState) (simapp.GenesisState, error) {
+	erc20Genesis, err := getCustomGenesisState(customGenesis, erc20types.ModuleName, erc20types.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+	erc20Genesis.TokenPairs = append(erc20Genesis.TokenPairs, overwriteParams.tokenPairs...)
+
+	var bankGenesis banktypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[banktypes.ModuleName], &bankGenesis)
+
+	bankDenoms := make(map[string]bool, len(bankGenesis.Supply)+len(bankGenesis.DenomMetadata))
+	for _, coin := range bankGenesis.Supply {
+		bankDenoms[coin.Denom] = true
+	}
+	for _, metadata := range bankGenesis.DenomMetadata {
+		bankDenoms[metadata.Base] = true
+	}
+
+	for _, tokenPair := range erc20Genesis.TokenPairs {
+		if !bankDenoms[tokenPair.Denom] {
+			return nil, fmt.Errorf("token pair denom %s has no metadata or supply in bank genesis", tokenPair.Denom)
+		}
+	}
+
+	genesisState[erc20types.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(erc20Genesis)
+	return genesisState, nil
+}
+
+// setTransferGenesisState sets the IBC transfer genesis state. If no custom transfer
+// genesis is provided, the default genesis state is used.
+// It returns an error if the transfer genesis is invalid or if the total escrowed
+// amount of any denomination is not backed by its supply in the bank genesis state.
+func setTransferGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState) (simapp.GenesisState, error) {
+	transferGenesis, err := getCustomGenesisState(customGenesis, ibctransfertypes.ModuleName, ibctransfertypes.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transferGenesis.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transfer genesis state: %w", err)
+	}
+
+	var bankGenesis banktypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[banktypes.ModuleName], &bankGenesis)
+
+	for _, escrowed := range transferGenesis.TotalEscrowed {
+		supply := bankGenesis.Supply.AmountOf(escrowed.Denom)
+		if supply.LT(escrowed.Amount) {
+			return nil, fmt.Errorf("escrowed amount %s exceeds the bank genesis supply of %s%s", escrowed, supply, escrowed.Denom)
+		}
+	}
+
+	genesisState[ibctransfertypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(transferGenesis)
+	return genesisState, nil
+}
+
+// setCapabilityGenesisState sets the capability genesis state. If no custom capability
+// genesis is provided, the default genesis state is used.
+//
+// NOTE: the in-memory capabilities are initialized from the capability owners by the
+// capability module on the first BeginBlock, so they resolve from the first block on.
+func setCapabilityGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState) (simapp.GenesisState, error) {
+	capabilityGenesis, err := getCustomGenesisState(customGenesis, capabilitytypes.ModuleName, capabilitytypes.DefaultGenesis())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := capabilityGenesis.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid capability genesis state: %w", err)
+	}
+
+	genesisState[capabilitytypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(capabilityGenesis)
+	return genesisState, nil
+}
+
+// setIBCGenesisState sets the IBC core genesis state. If no custom IBC genesis
+// is provided, the default genesis state is used.
+// It returns an error if any connection refers to a client that is not part of the
+// genesis clients, or if any channel refers to a connection that is not part of the
+// genesis connections.
+func setIBCGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState) (simapp.GenesisState, error) {
+	ibcGenesis, err := getCustomGenesisState(customGenesis, ibcexported.ModuleName, ibccoretypes.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make(map[string]bool, len(ibcGenesis.ClientGenesis.Clients))
+	for _, client := range ibcGenesis.ClientGenesis.Clients {
+		clients[client.ClientId] = true
+	}
+
+	connections := make(map[string]bool, len(ibcGenesis.ConnectionGenesis.Connections))
+	for _, connection := range ibcGenesis.ConnectionGenesis.Connections {
+		if !clients[connection.ClientId] {
+			return nil, fmt.Errorf("client %s of connection %s not found in genesis clients", connection.ClientId, connection.Id)
+		}
+		connections[connection.Id] = true
+	}
+
+	for _, channel := range ibcGenesis.ChannelGenesis.Channels {
+		for _, connectionID := range channel.ConnectionHops {
+			if !connections[connectionID] {
+				return nil, fmt.Errorf("connection %s of channel %s not found in genesis connections", connectionID, channel.ChannelId)
+			}
+		}
+	}
+
+	genesisState[ibcexported.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(ibcGenesis)
+	return genesisState, nil
+}
+
+// AuthCustomGenesisState defines the accounts to include in the auth genesis state.
+type AuthCustomGenesisState struct {
+	genAccounts     []authtypes.GenesisAccount
+	vestingAccounts []VestingAccount
+	genesisTime     time.Time
+}
+
+// setAuthGenesisState sets the auth genesis state. The vesting accounts are created
+// as clawback vesting accounts whose schedules start at the genesis time.
+func setAuthGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, authParams AuthCustomGenesisState) (simapp.GenesisState, error) {
+	vestingAccounts, err := createClawbackVestingAccounts(authParams.vestingAccounts, authParams.genesisTime)
+	if err != nil {
+		return nil, err
+	}
+
+	genAccounts := make([]authtypes.GenesisAccount, 0, len(authParams.genAccounts)+len(vestingAccounts))
+	genAccounts = append(genAccounts, authParams.genAccounts...)
+	genAccounts = append(genAccounts, vestingAccounts...)
+
+	authGenesis := authtypes.NewGenesisState(authtypes.DefaultParams(), genAccounts)
+	if err := authtypes.ValidateGenesis(*authGenesis); err != nil {
+		return nil, err
+	}
+	genesisState[authtypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(authGenesis)
+	return genesisState, nil
+}
+
+// EpochsCustomGenesisState defines the epochs registered on top of the epochs genesis state
+type EpochsCustomGenesisState struct {
+	epochs []epochstypes.EpochInfo
+}
+
+// setEpochsGenesisState sets the epochs genesis state. If no custom epochs genesis
+// is provided, the default genesis state with the day and week epochs is used.
+// The epochs from the given params are registered in addition to the genesis ones.
+// It returns an error if the resulting epochs genesis is invalid.
+func setEpochsGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, overwriteParams EpochsCustomGenesisState) (simapp.GenesisState, error) {
+	epochsGenesis, err := getCustomGenesisState(customGenesis, epochstypes.ModuleName, epochstypes.DefaultGenesisState())
+	if err != nil {
+		return nil, err
+	}
+	epochsGenesis.Epochs = append(epochsGenesis.Epochs, overwriteParams.epochs...)
+
+	if err := epochsGenesis.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid epochs genesis state: %w", err)
+	}
+
+	genesisState[epochstypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(epochsGenesis)
+	return genesisState, nil
+}
+
+// InflationCustomGenesisState defines the inflation schedule of the network
+type InflationCustomGenesisState struct {
+	enabled         bool
+	epochIdentifier string
+	epochsPerPeriod int64
+}
+
+// setInflationGenesisState sets the inflation genesis state. If no custom inflation
+// genesis is provided, the default genesis state with inflation disabled is used.
+// If inflation is enabled on the given params, it is enabled on top of the genesis
+// state for the given epoch schedule.
+// It returns an error if the epoch identifier is not part of the epochs genesis
+// or if the resulting inflation genesis is invalid.
+//
+// NOTE: the Evmos app does not register the SDK x/mint module, so the inflation
+// module is the only module minting new tokens and there is no mint genesis to set.
+func setInflationGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, customGenesis CustomGenesisState, overwriteParams InflationCustomGenesisState) (simapp.GenesisState, error) {
+	defaultGenesis := infltypes.DefaultGenesisState()
+	defaultGenesis.Params.EnableInflation = false
+	inflationGenesis, err := getCustomGenesisState(customGenesis, infltypes.ModuleName, defaultGenesis)
+	if err != nil {
+		return nil, err
+	}
+
+	if overwriteParams.enabled {
+		inflationGenesis.Params.EnableInflation = true
+		inflationGenesis.EpochIdentifier = overwriteParams.epochIdentifier
+		inflationGenesis.EpochsPerPeriod = overwriteParams.epochsPerPeriod
+	}
+
+	if inflationGenesis.EpochsPerPeriod <= 0 {
+		return nil, fmt.Errorf("invalid epochs per period %d: must be positive", inflationGenesis.EpochsPerPeriod)
+	}
+	if err := inflationGenesis.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid inflation genesis state: %w", err)
+	}
+
+	var epochsGenesis epochstypes.GenesisState
+	evmosApp.AppCodec().MustUnmarshalJSON(genesisState[epochstypes.ModuleName], &epochsGenesis)
+
+	found := false
+	for _, epoch := range epochsGenesis.Epochs {
+		if epoch.Identifier == inflationGenesis.EpochIdentifier {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("inflation epoch identifier %s not found in epochs genesis", inflationGenesis.EpochIdentifier)
+	}
+
+	genesisState[infltypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(inflationGenesis)
+	return genesisState, nil
+}
+
+type BankCustomGenesisState struct {
+	totalSupply sdktypes.Coins
+	balances    []banktypes.Balance
+	metadata    []banktypes.Metadata
+	sendEnabled []banktypes.SendEnabled
+}
+
+// setBankGenesisState sets the bank genesis state.
+// It returns an error if any of the denom metadata is invalid, e.g. if the base denom
+// is not the first denom unit with exponent 0, or if its base denom has no supply,
+// and if the send enabled entries are invalid.
+func setBankGenesisState(evmosApp *app.Evmos, genesisState simapp.GenesisState, overwriteParams BankCustomGenesisState) (simapp.GenesisState, error) {
+	for _, metadata := range overwriteParams.metadata {
+		if err := metadata.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid metadata for denom %s: %w", metadata.Base, err)
+		}
+		if overwriteParams.totalSupply.AmountOf(metadata.Base).IsZero() {
+			return nil, fmt.Errorf("metadata base denom %s has no supply", metadata.Base)
+		}
+	}
+
+	sendEnabledDenoms := make(map[string]bool, len(overwriteParams.sendEnabled))
+	for _, sendEnabled := range overwriteParams.sendEnabled {
+		if err := sendEnabled.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid send enabled entry for denom %s: %w", sendEnabled.Denom, err)
+		}
+		if sendEnabledDenoms[sendEnabled.Denom] {
+			return nil, fmt.Errorf("duplicate send enabled entry for denom %s", sendEnabled.Denom)
+		}
+		sendEnabledDenoms[sendEnabled.Denom] = true
+	}
+
+	bankGenesis := banktypes.NewGenesisState(
+		banktypes.DefaultGenesisState().Params,
+		overwriteParams.balances,
+		overwriteParams.totalSupply,
+		overwriteParams.metadata,
+		overwriteParams.sendEnabled,
+	)
+	genesisState[banktypes.ModuleName] = evmosApp.AppCodec().MustMarshalJSON(bankGenesis)
+	return genesisState, nil
+}
+
+// addStakingPoolBalances adds the bonded and not bonded amounts to the bonded and not bonded
+// pool module accounts and includes them on funded accounts. Pools with a zero amount are
+// not funded. It returns an error if the denom of any of the amounts differs from the
+// given staking bond denom.
+func addStakingPoolBalances(fundedAccountsBalances []banktypes.Balance, bonded, notBonded sdktypes.Coin, bondDenom string) ([]banktypes.Balance, error) {
+	if notBonded.Denom != bondDenom {
+		return nil, fmt.Errorf("not bonded pool denom %s doesn't match the staking bond denom %s", notBonded.Denom, bondDenom)
+	}
+
+	var err error
+	if bonded.IsPositive() {
+		fundedAccountsBalances, err = addBondedModuleAccountToFundedBalances(fundedAccountsBalances, bonded, bondDenom)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if notBonded.IsPositive() {
+		fundedAccountsBalances = addNotBondedModuleAccountToFundedBalances(fundedAccountsBalances, notBonded)
+	}
+	return fundedAccountsBalances, nil
+}
+
+// getUnbondingDelegationsBalance returns the total balance of the entries of the given
+// unbonding delegations, which is held by the not bonded pool.
+func getUnbondingDelegationsBalance(unbondingDelegations []stakingtypes.UnbondingDelegation) sdkmath.Int {
+	total := sdkmath.ZeroInt()
+	for _, ubd := range unbondingDelegations {
+		for _, entry := range ubd.Entries {
+			total = total.Add(entry.Balance)
+		}
+	}
+	return total
+}
+
+// addNotBondedModuleAccountToFundedBalances adds the not bonded amount to the not bonded pool module account
+// and includes it on funded accounts
+func addNotBondedModuleAccountToFundedBalances(fundedAccountsBalances []banktypes.Balance, totalNotBonded sdktypes.Coin) []banktypes.Balance {
+	return append(fundedAccountsBalances, banktypes.Balance{
+		Address: authtypes.NewModuleAddress(stakingtypes.NotBondedPoolName).String(),
+		Coins:   sdktypes.Coins{totalNotBonded},
+	})
+}
+
+// addDistributionModuleAccountToFundedBalances adds the amount required to back the outstanding rewards
+// and the community pool of the given distribution genesis to the distribution module account
+// and includes it on funded accounts
+func addDistributionModuleAccountToFundedBalances(fundedAccountsBalances []banktypes.Balance, distrGenesis *distrtypes.GenesisState) []banktypes.Balance {
+	var moduleHoldings sdktypes.DecCoins
+	for _, rewards := range distrGenesis.OutstandingRewards {
+		moduleHoldings = moduleHoldings.Add(rewards.OutstandingRewards...)
+	}
+	moduleHoldings = moduleHoldings.Add(distrGenesis.FeePool.CommunityPool...)
+
+	moduleHoldingsInt, _ := moduleHoldings.TruncateDecimal()
+	if moduleHoldingsInt.IsZero() {
+		return fundedAccountsBalances
+	}
+
+	return append(fundedAccountsBalances, banktypes.Balance{
+		Address: authtypes.NewModuleAddress(distrtypes.ModuleName).String(),
+		Coins:   moduleHoldingsInt,
+	})
+}
+
+// validateDistributionModuleBalance checks that the balance of the distribution module
+// account in the given balances covers the truncated amount of the outstanding rewards
+// and the community pool of the given distribution genesis.
+// It returns an error if the distribution module account is underfunded.
+func validateDistributionModuleBalance(fundedAccountsBalances []banktypes.Balance, distrGenesis *distrtypes.GenesisState) error {
+	var moduleHoldings sdktypes.DecCoins
+	for _, rewards := range distrGenesis.OutstandingRewards {
+		moduleHoldings = moduleHoldings.Add(rewards.OutstandingRewards...)
+	}
+	moduleHoldings = moduleHoldings.Add(distrGenesis.FeePool.CommunityPool...)
+	expHoldings, _ := moduleHoldings.TruncateDecimal()
+
+	moduleAddr := authtypes.NewModuleAddress(distrtypes.ModuleName).String()
+	moduleBalance := sdktypes.NewCoins()
+	for _, balance := range fundedAccountsBalances {
+		if balance.Address == moduleAddr {
+			moduleBalance = moduleBalance.Add(balance.Coins...)
+		}
+	}
+
+	if !moduleBalance.IsAllGTE(expHoldings) {
+		return fmt.Errorf("distribution module account balance %s doesn't cover the outstanding rewards and community pool %s", moduleBalance, expHoldings)
+	}
+	return nil
+}
+
+// addGovModuleAccountToFundedBalances adds the total amount deposited on the proposals of the
+// given gov genesis to the gov module account and includes it on funded accounts
+func addGovModuleAccountToFundedBalances(fundedAccountsBalances []banktypes.Balance, govGenesis *govv1types.GenesisState) []banktypes.Balance {
+	totalDeposits := sdktypes.NewCoins()
+	for _, deposit := range govGenesis.Deposits {
+		totalDeposits = totalDeposits.Add(deposit.Amount...)
+	}
+
+	if totalDeposits.IsZero() {
+		return fundedAccountsBalances
+	}
+
+	return append(fundedAccountsBalances, banktypes.Balance{
+		Address: authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+		Coins:   totalDeposits,
+	})
+}
+
+// addFeeCollectorModuleAccountToFundedBalances adds the given coins to the fee collector
+// module account and includes it on funded accounts. The coins are included in the total
+// supply as any other funded balance.
+// It returns an error if the coins are invalid or if the fee collector is already funded.
+func addFeeCollectorModuleAccountToFundedBalances(fundedAccountsBalances []banktypes.Balance, coins sdktypes.Coins) ([]banktypes.Balance, error) {
+	if coins.IsZero() {
+		return fundedAccountsBalances, nil
+	}
+	if err := coins.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fee collector balance: %w", err)
+	}
+
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName).String()
+	for _, balance := range fundedAccountsBalances {
+		if balance.Address == feeCollector {
+			return nil, fmt.Errorf("fee collector module account %s is already funded", feeCollector)
+		}
+	}
+
+	return append(fundedAccountsBalances, banktypes.Balance{
+		Address: feeCollector,
+		Coins:   coins,
+	}), nil
+}
+
+// calculateTotalSupply calculates the total supply from the given balances.
+// The amounts are accumulated by denom in a single pass, so that the coins
+// are only sorted once instead of on every balance.
+func calculateTotalSupply(fundedAccountsBalances []banktypes.Balance) sdktypes.Coins {
+	amounts := make(map[string]sdkmath.Int)
+	for _, balance := range fundedAccountsBalances {
+		for _, coin := range balance.Coins {
+			amount, found := amounts[coin.Denom]
+			if !found {
+				amount = sdkmath.ZeroInt()
+			}
+			amounts[coin.Denom] = amount.Add(coin.Amount)
+		}
+	}
+
+	totalSupply := make(sdktypes.Coins, 0, len(amounts))
+	for denom, amount := range amounts {
+		totalSupply = append(totalSupply, sdktypes.NewCoin(denom, amount))
+	}
+	return sdktypes.NewCoins(totalSupply...)
+}
+
+// addBondedModuleAccountToFundedBalances adds bonded amount to bonded pool module account and include it on funded accounts.
+// It returns an error if the bonded amount denom differs from the staking bond denom.
+func addBondedModuleAccountToFundedBalances(fundedAccountsBalances []banktypes.Balance, totalBonded sdktypes.Coin, bondDenom string) ([]banktypes.Balance, error) {
+	if totalBonded.Denom != bondDenom {
+		return nil, fmt.Errorf("bonded pool denom %s doesn't match the staking bond denom %s", totalBonded.Denom, bondDenom)
+	}
+
+	return append(fundedAccountsBalances, banktypes.Balance{
+		Address: authtypes.NewModuleAddress(stakingtypes.BondedPoolName).String(),
+		Coins:   sdktypes.Coins{totalBonded},
+	}), nil
 }
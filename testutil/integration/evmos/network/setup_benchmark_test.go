@@ -0,0 +1,73 @@
+package network
+
+import (
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	testtx "github.com/evmos/evmos/v16/testutil/tx"
+	"github.com/evmos/evmos/v16/utils"
+)
+
+// benchmarkAccounts is the amount of accounts funded on the genesis benchmarks
+const benchmarkAccounts = 10_000
+
+func benchmarkFundedAccounts() ([]sdktypes.AccAddress, sdktypes.Coins) {
+	accounts := make([]sdktypes.AccAddress, 0, benchmarkAccounts)
+	for i := 0; i < benchmarkAccounts; i++ {
+		acc, _ := testtx.NewAccAddressAndKey()
+		accounts = append(accounts, acc)
+	}
+	coins := sdktypes.NewCoins(
+		sdktypes.NewInt64Coin(utils.BaseDenom, 1e18),
+		sdktypes.NewInt64Coin(ibcDenom, 1e6),
+	)
+	return accounts, coins
+}
+
+// createBalancesPerCoin is the previous way of funding the accounts with multiple
+// coins, which creates the balances of one coin at a time with createBalances and
+// adds them to the coins of each account
+func createBalancesPerCoin(accounts []sdktypes.AccAddress, coins sdktypes.Coins) []banktypes.Balance {
+	var balances []banktypes.Balance
+	for _, coin := range coins {
+		for i, balance := range createBalances(accounts, coin) {
+			if i == len(balances) {
+				balances = append(balances, balance)
+				continue
+			}
+			balances[i].Coins = balances[i].Coins.Add(balance.Coins...)
+		}
+	}
+	return balances
+}
+
+// addTotalSupply is the previous total supply calculation, which adds the
+// coins of each balance to the total supply
+func addTotalSupply(balances []banktypes.Balance) sdktypes.Coins {
+	totalSupply := sdktypes.NewCoins()
+	for _, balance := range balances {
+		totalSupply = totalSupply.Add(balance.Coins...)
+	}
+	return totalSupply
+}
+
+func BenchmarkFundAccounts(b *testing.B) {
+	accounts, coins := benchmarkFundedAccounts()
+	b.Run("createBalancesPerCoin", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			addTotalSupply(createBalancesPerCoin(accounts, coins))
+		}
+	})
+	b.Run("createBalancesBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			balances, err := createBalancesBatch(accounts, coins)
+			if err != nil {
+				b.Fatal(err)
+			}
+			calculateTotalSupply(balances)
+		}
+	})
+}
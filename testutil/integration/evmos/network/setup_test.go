@@ -0,0 +1,1274 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	sdkvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1types "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	cosmosante "github.com/evmos/evmos/v16/app/ante/cosmos"
+	testtx "github.com/evmos/evmos/v16/testutil/tx"
+	"github.com/evmos/evmos/v16/utils"
+	epochstypes "github.com/evmos/evmos/v16/x/epochs/types"
+	evmtypes "github.com/evmos/evmos/v16/x/evm/types"
+	inflationtypes "github.com/evmos/evmos/v16/x/inflation/v1/types"
+	vestingtypes "github.com/evmos/evmos/v16/x/vesting/types"
+)
+
+const ibcDenom = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+func TestCreateBalancesMulti(t *testing.T) {
+	accounts := make([]sdktypes.AccAddress, 0, 3)
+	for i := 0; i < 3; i++ {
+		acc, _ := testtx.NewAccAddressAndKey()
+		accounts = append(accounts, acc)
+	}
+
+	coins := sdktypes.NewCoins(
+		sdktypes.NewInt64Coin(utils.BaseDenom, 100),
+		sdktypes.NewInt64Coin(ibcDenom, 50),
+	)
+
+	balances := createBalancesMulti(accounts, coins)
+	require.Len(t, balances, len(accounts))
+	for i, balance := range balances {
+		require.Equal(t, accounts[i].String(), balance.Address)
+		require.True(t, coins.IsEqual(balance.Coins))
+	}
+
+	expSupply := sdktypes.NewCoins(
+		sdktypes.NewInt64Coin(utils.BaseDenom, 300),
+		sdktypes.NewInt64Coin(ibcDenom, 150),
+	)
+	require.True(t, expSupply.IsEqual(calculateTotalSupply(balances)))
+}
+
+func TestCreateBalancesBatch(t *testing.T) {
+	accounts := make([]sdktypes.AccAddress, 0, 3)
+	for i := 0; i < 3; i++ {
+		acc, _ := testtx.NewAccAddressAndKey()
+		accounts = append(accounts, acc)
+	}
+	coins := sdktypes.NewCoins(
+		sdktypes.NewInt64Coin(utils.BaseDenom, 100),
+		sdktypes.NewInt64Coin(ibcDenom, 10),
+	)
+
+	balances, err := createBalancesBatch(accounts, coins)
+	require.NoError(t, err)
+	require.Len(t, balances, len(accounts))
+	for i, balance := range balances {
+		require.Equal(t, accounts[i].String(), balance.Address)
+		require.True(t, coins.IsEqual(balance.Coins))
+	}
+
+	expSupply := sdktypes.NewCoins(
+		sdktypes.NewInt64Coin(utils.BaseDenom, 300),
+		sdktypes.NewInt64Coin(ibcDenom, 30),
+	)
+	require.True(t, expSupply.IsEqual(calculateTotalSupply(balances)))
+
+	// The balances don't share their coins with each other nor with the given coins
+	balances[0].Coins[0].Amount = sdktypes.NewInt(1)
+	require.True(t, coins.IsEqual(balances[1].Coins))
+	require.Equal(t, int64(100), coins.AmountOf(utils.BaseDenom).Int64())
+	balances[1].Coins = append(balances[1].Coins, sdktypes.NewInt64Coin("zzz", 1))
+	require.True(t, coins.IsEqual(balances[2].Coins))
+
+	unsortedCoins := sdktypes.Coins{
+		sdktypes.NewInt64Coin(ibcDenom, 10),
+		sdktypes.NewInt64Coin(utils.BaseDenom, 100),
+	}
+	_, err = createBalancesBatch(accounts, unsortedCoins)
+	require.Error(t, err)
+}
+
+func TestCreateBalancesPerAccount(t *testing.T) {
+	acc1, _ := testtx.NewAccAddressAndKey()
+	acc2, _ := testtx.NewAccAddressAndKey()
+	accounts := []sdktypes.AccAddress{acc1, acc2}
+
+	testCases := []struct {
+		name      string
+		coins     []sdktypes.Coins
+		expPass   bool
+		expSupply sdktypes.Coins
+	}{
+		{
+			"fail - less coin sets than accounts",
+			[]sdktypes.Coins{sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 100))},
+			false,
+			nil,
+		},
+		{
+			"pass - distinct coin sets per account",
+			[]sdktypes.Coins{
+				sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 100)),
+				sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 10), sdktypes.NewInt64Coin(ibcDenom, 50)),
+			},
+			true,
+			sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 110), sdktypes.NewInt64Coin(ibcDenom, 50)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			balances, err := createBalancesPerAccount(accounts, tc.coins)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, balances, len(accounts))
+			require.True(t, tc.expSupply.IsEqual(calculateTotalSupply(balances)))
+		})
+	}
+}
+
+func TestCreateClawbackVestingAccounts(t *testing.T) {
+	vestingAddr, _ := testtx.NewAccAddressAndKey()
+	funder, _ := testtx.NewAccAddressAndKey()
+	startTime := time.Unix(1700000000, 0).UTC()
+
+	periods := sdkvesting.Periods{
+		{Length: 100, Amount: sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 100))},
+		{Length: 100, Amount: sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 200))},
+	}
+	expVesting := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 300))
+
+	testCases := []struct {
+		name           string
+		vestingAccount VestingAccount
+		expPass        bool
+	}{
+		{
+			"fail - funder not set",
+			VestingAccount{Address: vestingAddr, VestingPeriods: periods},
+			false,
+		},
+		{
+			"fail - lockup and vesting amounts differ",
+			VestingAccount{Address: vestingAddr, Funder: funder, LockupPeriods: periods[:1], VestingPeriods: periods},
+			false,
+		},
+		{
+			"pass - instant lockup",
+			VestingAccount{Address: vestingAddr, Funder: funder, VestingPeriods: periods},
+			true,
+		},
+		{
+			"pass - lockup and vesting schedules",
+			VestingAccount{Address: vestingAddr, Funder: funder, LockupPeriods: periods, VestingPeriods: periods},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			genAccounts, err := createClawbackVestingAccounts([]VestingAccount{tc.vestingAccount}, startTime)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, genAccounts, 1)
+
+			vestingAcc, ok := genAccounts[0].(*vestingtypes.ClawbackVestingAccount)
+			require.True(t, ok)
+			require.Equal(t, funder.String(), vestingAcc.FunderAddress)
+			require.Equal(t, startTime.Unix(), vestingAcc.GetStartTime())
+			require.True(t, expVesting.IsEqual(vestingAcc.OriginalVesting))
+		})
+	}
+}
+
+func TestCreateValidatorSetFromDeterministicPrivVals(t *testing.T) {
+	valSet, _, err := createValidatorSetFromPrivVals(deterministicPrivVals(1, 3))
+	require.NoError(t, err)
+	require.Len(t, valSet.Validators, 3)
+
+	sameValSet, _, err := createValidatorSetFromPrivVals(deterministicPrivVals(1, 3))
+	require.NoError(t, err)
+	require.Equal(t, valSet.Hash(), sameValSet.Hash())
+
+	otherValSet, _, err := createValidatorSetFromPrivVals(deterministicPrivVals(2, 3))
+	require.NoError(t, err)
+	require.NotEqual(t, valSet.Hash(), otherValSet.Hash())
+
+	privVals := deterministicPrivVals(1, 1)
+	_, _, err = createValidatorSetFromPrivVals(append(privVals, privVals...))
+	require.Error(t, err)
+}
+
+func TestAddBondedModuleAccountToFundedBalances(t *testing.T) {
+	testCases := []struct {
+		name        string
+		totalBonded sdktypes.Coin
+		expPass     bool
+	}{
+		{
+			"fail - bonded denom differs from bond denom",
+			sdktypes.NewInt64Coin(ibcDenom, 100),
+			false,
+		},
+		{
+			"pass - bonded denom matches bond denom",
+			sdktypes.NewInt64Coin(utils.BaseDenom, 100),
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			balances, err := addBondedModuleAccountToFundedBalances(nil, tc.totalBonded, utils.BaseDenom)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, balances, 1)
+			require.True(t, sdktypes.NewCoins(tc.totalBonded).IsEqual(balances[0].Coins))
+		})
+	}
+}
+
+func TestAddStakingPoolBalances(t *testing.T) {
+	testCases := []struct {
+		name        string
+		bonded      sdktypes.Coin
+		notBonded   sdktypes.Coin
+		expPass     bool
+		expBalances int
+	}{
+		{
+			"fail - not bonded pool denom differs from the bond denom",
+			sdktypes.NewInt64Coin(utils.BaseDenom, 100),
+			sdktypes.NewInt64Coin(ibcDenom, 100),
+			false,
+			0,
+		},
+		{
+			"fail - bonded pool denom differs from the bond denom",
+			sdktypes.NewInt64Coin(ibcDenom, 100),
+			sdktypes.NewInt64Coin(utils.BaseDenom, 100),
+			false,
+			0,
+		},
+		{
+			"fail - zero amounts in a denom other than the bond denom",
+			sdktypes.NewInt64Coin(ibcDenom, 0),
+			sdktypes.NewInt64Coin(ibcDenom, 0),
+			false,
+			0,
+		},
+		{
+			"pass - zero not bonded amount is not funded",
+			sdktypes.NewInt64Coin(utils.BaseDenom, 100),
+			sdktypes.NewInt64Coin(utils.BaseDenom, 0),
+			true,
+			1,
+		},
+		{
+			"pass - both pools funded",
+			sdktypes.NewInt64Coin(utils.BaseDenom, 100),
+			sdktypes.NewInt64Coin(utils.BaseDenom, 50),
+			true,
+			2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			balances, err := addStakingPoolBalances(nil, tc.bonded, tc.notBonded, utils.BaseDenom)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, balances, tc.expBalances)
+			require.True(t, sdktypes.NewCoins(tc.bonded, tc.notBonded).IsEqual(calculateTotalSupply(balances)))
+		})
+	}
+}
+
+func TestDeterministicAccounts(t *testing.T) {
+	accounts, privKeys := DeterministicAccounts(1, 3)
+	require.Len(t, accounts, 3)
+	require.Len(t, privKeys, 3)
+
+	for i, privKey := range privKeys {
+		key, err := privKey.ToECDSA()
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey).Bytes(), accounts[i].Bytes())
+	}
+
+	sameAccounts, _ := DeterministicAccounts(1, 3)
+	require.Equal(t, accounts, sameAccounts)
+
+	otherAccounts, _ := DeterministicAccounts(2, 3)
+	require.NotEqual(t, accounts, otherAccounts)
+}
+
+func TestCreateGenesisAccountsWithMeta(t *testing.T) {
+	acc1, _ := testtx.NewAccAddressAndKey()
+	acc2, _ := testtx.NewAccAddressAndKey()
+	accounts := []sdktypes.AccAddress{acc1, acc2}
+
+	testCases := []struct {
+		name    string
+		seqs    []uint64
+		numbers []uint64
+		expPass bool
+	}{
+		{
+			"fail - less sequences than accounts",
+			[]uint64{1},
+			[]uint64{0, 1},
+			false,
+		},
+		{
+			"fail - less account numbers than accounts",
+			[]uint64{1, 2},
+			[]uint64{0},
+			false,
+		},
+		{
+			"fail - duplicate account numbers",
+			[]uint64{1, 2},
+			[]uint64{3, 3},
+			false,
+		},
+		{
+			"pass - custom sequences and account numbers",
+			[]uint64{1, 2},
+			[]uint64{3, 4},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			genAccounts, err := createGenesisAccountsWithMeta(accounts, tc.seqs, tc.numbers)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, genAccounts, len(accounts))
+			for i, genAccount := range genAccounts {
+				require.Equal(t, tc.seqs[i], genAccount.GetSequence())
+				require.Equal(t, tc.numbers[i], genAccount.GetAccountNumber())
+			}
+		})
+	}
+}
+
+func TestSendDisabledDenom(t *testing.T) {
+	nw := New(
+		WithSendEnabled(banktypes.SendEnabled{Denom: utils.BaseDenom, Enabled: false}),
+	)
+	ctx := nw.GetContext()
+	sender := nw.cfg.preFundedAccounts[0]
+	receiver, _ := testtx.NewAccAddressAndKey()
+	coin := sdktypes.NewInt64Coin(utils.BaseDenom, 1000)
+
+	bankMsgServer := bankkeeper.NewMsgServerImpl(nw.app.BankKeeper)
+	_, err := bankMsgServer.Send(ctx, banktypes.NewMsgSend(sender, receiver, sdktypes.NewCoins(coin)))
+	require.ErrorIs(t, err, banktypes.ErrSendDisabled)
+
+	valAddr, err := sdktypes.ValAddressFromBech32(nw.GetValidators()[0].OperatorAddress)
+	require.NoError(t, err)
+	stakingMsgServer := stakingkeeper.NewMsgServerImpl(&nw.app.StakingKeeper)
+	_, err = stakingMsgServer.Delegate(ctx, stakingtypes.NewMsgDelegate(sender, valAddr, coin))
+	require.NoError(t, err)
+}
+
+func TestCreateDelegationsMulti(t *testing.T) {
+	valSet, _, err := createValidatorSetFromPrivVals(deterministicPrivVals(1, 2))
+	require.NoError(t, err)
+	accounts, _ := DeterministicAccounts(1, 3)
+	valAddrs := []sdktypes.ValAddress{valSet.Validators[0].Address.Bytes(), valSet.Validators[1].Address.Bytes()}
+
+	testCases := []struct {
+		name        string
+		delegations []stakingtypes.Delegation
+		expShares   []sdktypes.Dec
+		expLen      int
+		expPass     bool
+	}{
+		{
+			"pass - no delegations delegates one share from the default delegator",
+			nil,
+			[]sdktypes.Dec{sdktypes.OneDec(), sdktypes.OneDec()},
+			2,
+			true,
+		},
+		{
+			"pass - multiple delegators with different shares",
+			[]stakingtypes.Delegation{
+				stakingtypes.NewDelegation(accounts[1], valAddrs[0], sdktypes.NewDec(3)),
+				stakingtypes.NewDelegation(accounts[2], valAddrs[0], sdktypes.NewDec(1)),
+				stakingtypes.NewDelegation(accounts[2], valAddrs[1], sdktypes.NewDec(5)),
+			},
+			[]sdktypes.Dec{sdktypes.NewDec(4), sdktypes.NewDec(5)},
+			3,
+			true,
+		},
+		{
+			"pass - validator without delegations gets the default delegation",
+			[]stakingtypes.Delegation{
+				stakingtypes.NewDelegation(accounts[1], valAddrs[1], sdktypes.NewDec(2)),
+			},
+			[]sdktypes.Dec{sdktypes.OneDec(), sdktypes.NewDec(2)},
+			2,
+			true,
+		},
+		{
+			"fail - unknown validator",
+			[]stakingtypes.Delegation{
+				stakingtypes.NewDelegation(accounts[1], sdktypes.ValAddress(accounts[2]), sdktypes.OneDec()),
+			},
+			nil,
+			0,
+			false,
+		},
+		{
+			"fail - non positive shares",
+			[]stakingtypes.Delegation{
+				stakingtypes.NewDelegation(accounts[1], valAddrs[0], sdktypes.ZeroDec()),
+			},
+			nil,
+			0,
+			false,
+		},
+		{
+			"fail - duplicate delegation",
+			[]stakingtypes.Delegation{
+				stakingtypes.NewDelegation(accounts[1], valAddrs[0], sdktypes.OneDec()),
+				stakingtypes.NewDelegation(accounts[1], valAddrs[0], sdktypes.OneDec()),
+			},
+			nil,
+			0,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validators, err := createStakingValidators(valSet.Validators, nil, time.Time{})
+			require.NoError(t, err)
+
+			delegations, err := createDelegationsMulti(validators, accounts[0], tc.delegations)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, delegations, tc.expLen)
+			for i, val := range validators {
+				require.True(t, tc.expShares[i].Equal(val.DelegatorShares), "expected %s, got %s", tc.expShares[i], val.DelegatorShares)
+
+				sum := sdktypes.ZeroDec()
+				for _, delegation := range delegations {
+					if delegation.ValidatorAddress == val.OperatorAddress {
+						sum = sum.Add(delegation.Shares)
+					}
+				}
+				require.True(t, sum.Equal(val.DelegatorShares))
+			}
+		})
+	}
+}
+
+func TestDelegatorSharesInvariant(t *testing.T) {
+	accounts, _ := DeterministicAccounts(1, 2)
+	privVals := deterministicPrivVals(1, 3)
+	valSet, _, err := createValidatorSetFromPrivVals(privVals)
+	require.NoError(t, err)
+	valAddr := sdktypes.ValAddress(valSet.Validators[0].Address)
+
+	nw := New(
+		WithValidatorPrivVals(privVals...),
+		WithPreFundedAccounts(accounts...),
+		WithDelegations(
+			stakingtypes.NewDelegation(accounts[0], valAddr, sdktypes.NewDec(2)),
+			stakingtypes.NewDelegation(accounts[1], valAddr, sdktypes.NewDec(3)),
+		),
+	)
+	require.NoError(t, nw.NextBlock())
+
+	msg, broken := stakingkeeper.DelegatorSharesInvariant(&nw.app.StakingKeeper)(nw.GetContext())
+	require.False(t, broken, msg)
+}
+
+func TestFeeCollectorBalance(t *testing.T) {
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 1e18))
+	defaultNw := New()
+	nw := New(
+		WithFeeCollectorBalance(coins),
+	)
+	expSupply := defaultNw.GetGenesisTotalSupply().Add(coins...)
+	require.NoError(t, nw.AssertTotalSupply(expSupply))
+
+	require.NoError(t, nw.NextBlock())
+	msg, broken := bankkeeper.TotalSupply(nw.app.BankKeeper)(nw.GetContext())
+	require.False(t, broken, msg)
+	require.NoError(t, nw.AssertTotalSupply(expSupply))
+}
+
+func TestMeasureGas(t *testing.T) {
+	nw := New()
+	sender := nw.cfg.preFundedAccounts[0]
+	receiver, _ := testtx.NewAccAddressAndKey()
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 1000))
+	balance := nw.app.BankKeeper.GetBalance(nw.GetContext(), sender, utils.BaseDenom)
+
+	// NOTE: the bank precompile only exposes queries, so the transfer is measured
+	// on the bank message server that executes the transfers of the precompiles.
+	bankMsgServer := bankkeeper.NewMsgServerImpl(nw.app.BankKeeper)
+	send := func(ctx sdktypes.Context) error {
+		_, err := bankMsgServer.Send(ctx, banktypes.NewMsgSend(sender, receiver, coins))
+		return err
+	}
+
+	gas, err := nw.MeasureGas(send)
+	require.NoError(t, err)
+	require.NotZero(t, gas)
+
+	sameGas, err := nw.MeasureGas(send)
+	require.NoError(t, err)
+	require.Equal(t, gas, sameGas)
+
+	// The state changes of the measured action are discarded
+	require.Equal(t, balance, nw.app.BankKeeper.GetBalance(nw.GetContext(), sender, utils.BaseDenom))
+	require.True(t, nw.app.BankKeeper.GetBalance(nw.GetContext(), receiver, utils.BaseDenom).IsZero())
+}
+
+func TestSendToBlockedAddress(t *testing.T) {
+	nw := New()
+	blocked := nw.FeeCollectorAddress()
+	require.True(t, nw.IsBlockedAddress(blocked))
+
+	sender := nw.cfg.preFundedAccounts[0]
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 1000))
+	bankMsgServer := bankkeeper.NewMsgServerImpl(nw.app.BankKeeper)
+	_, err := bankMsgServer.Send(nw.GetContext(), banktypes.NewMsgSend(sender, blocked, coins))
+	require.Error(t, err)
+
+	require.Panics(t, func() {
+		New(WithPreFundedAccounts(blocked))
+	})
+	require.Panics(t, func() {
+		New(WithPreFundedAccounts(sender), WithBlockedAddresses([]sdktypes.AccAddress{sender}))
+	})
+
+	customBlocked, _ := testtx.NewAccAddressAndKey()
+	nw = New(
+		WithBlockedAddresses([]sdktypes.AccAddress{customBlocked}),
+	)
+	require.True(t, nw.IsBlockedAddress(customBlocked))
+	bankMsgServer = bankkeeper.NewMsgServerImpl(nw.app.BankKeeper)
+	_, err = bankMsgServer.Send(nw.GetContext(), banktypes.NewMsgSend(nw.cfg.preFundedAccounts[0], customBlocked, coins))
+	require.ErrorContains(t, err, "not allowed to receive funds")
+}
+
+func TestUpgradePlan(t *testing.T) {
+	applied := false
+	handler := func(_ sdktypes.Context, _ upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		applied = true
+		return fromVM, nil
+	}
+	nw := New(
+		WithUpgradePlan(upgradetypes.Plan{Name: "test-upgrade", Height: 3}, handler),
+	)
+	require.NoError(t, nw.CommitBlocks(2))
+	require.True(t, applied)
+	_, found := nw.app.UpgradeKeeper.GetUpgradePlan(nw.GetContext())
+	require.False(t, found)
+
+	// The first block after the genesis commit has height 2
+	nw = New(
+		WithUpgradePlan(upgradetypes.Plan{Name: "unknown-upgrade", Height: 4}, nil),
+	)
+	require.NoError(t, nw.NextBlock())
+	require.ErrorIs(t, nw.NextBlock(), ErrUpgradeNeeded)
+	require.Equal(t, int64(3), nw.GetContext().BlockHeight())
+
+	require.Panics(t, func() {
+		New(WithUpgradePlan(upgradetypes.Plan{Name: "past-upgrade", Height: 1}, handler))
+	})
+}
+
+func TestUpgradePlanAfterSnapshot(t *testing.T) {
+	applied := 0
+	handler := func(_ sdktypes.Context, _ upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		applied++
+		return fromVM, nil
+	}
+	nw := New(
+		WithUpgradePlan(upgradetypes.Plan{Name: "test-upgrade", Height: 4}, handler),
+	)
+	id, err := nw.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), nw.GetContext().BlockHeight())
+
+	// The handler is registered again on the restored app
+	require.NoError(t, nw.RestoreSnapshot(id))
+	require.NoError(t, nw.NextBlock())
+	require.Equal(t, 1, applied)
+	_, found := nw.app.UpgradeKeeper.GetUpgradePlan(nw.GetContext())
+	require.False(t, found)
+
+	// The same snapshot can be upgraded again
+	require.NoError(t, nw.RestoreSnapshot(id))
+	require.NoError(t, nw.NextBlock())
+	require.Equal(t, 2, applied)
+}
+
+func TestAnteHandler(t *testing.T) {
+	errRejected := errors.New("rejected by the custom ante handler")
+	nw := New(
+		WithAnteHandler(func(ctx sdktypes.Context, _ sdktypes.Tx, _ bool) (sdktypes.Context, error) {
+			return ctx, errRejected
+		}),
+	)
+
+	sender := nw.cfg.preFundedAccounts[0]
+	receiver, _ := testtx.NewAccAddressAndKey()
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 1000))
+	txConfig := nw.app.GetTxConfig()
+	txBuilder := txConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(sender, receiver, coins)))
+	txBytes, err := txConfig.TxEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+
+	checkRes, err := nw.CheckTx(txBytes)
+	require.NoError(t, err)
+	require.True(t, checkRes.IsErr())
+	require.Contains(t, checkRes.Log, errRejected.Error())
+
+	deliverRes, err := nw.BroadcastTxSync(txBytes)
+	require.NoError(t, err)
+	require.True(t, deliverRes.IsErr())
+	require.Contains(t, deliverRes.Log, errRejected.Error())
+}
+
+func TestEventsByType(t *testing.T) {
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 1e18))
+	nw := New(
+		WithFeeCollectorBalance(coins),
+	)
+	genesisHeight := nw.GetContext().BlockHeight()
+
+	// The fees of the previous block are allocated on the BeginBlock of the next one
+	require.NoError(t, nw.NextBlock())
+	height := nw.GetContext().BlockHeight()
+	events, err := nw.EventsByType(height, banktypes.EventTypeTransfer)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	for _, event := range events {
+		require.Equal(t, banktypes.EventTypeTransfer, event.Type)
+	}
+
+	events, err = nw.EventsByType(height, "unknown")
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	_, err = nw.EventsByType(height+1, banktypes.EventTypeTransfer)
+	require.Error(t, err)
+
+	require.NoError(t, nw.CommitBlocks(retainedEventBlocks))
+	_, err = nw.EventsByType(genesisHeight, banktypes.EventTypeTransfer)
+	require.Error(t, err)
+}
+
+func TestErc20ContractAddress(t *testing.T) {
+	nw := New(
+		WithNativeErc20Pairs(NativeErc20Pair{Denom: "uatom", Name: "Atom", Symbol: "ATOM", Decimals: 6}),
+	)
+
+	contractAddr, found := nw.Erc20ContractAddress("uatom")
+	require.True(t, found)
+	code, err := nw.GetCode(contractAddr)
+	require.NoError(t, err)
+	require.NotEmpty(t, code)
+
+	_, found = nw.Erc20ContractAddress("unknown")
+	require.False(t, found)
+}
+
+func TestLockedVestingDelegation(t *testing.T) {
+	vestingAddr, _ := testtx.NewAccAddressAndKey()
+	funder, _ := testtx.NewAccAddressAndKey()
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 1000))
+	// All the coins vest and unlock after a cliff of one day
+	periods := sdkvesting.Periods{{Length: 86400, Amount: coins}}
+	nw := New(
+		WithVestingAccounts(VestingAccount{Address: vestingAddr, Funder: funder, LockupPeriods: periods, VestingPeriods: periods}),
+	)
+	require.True(t, nw.SpendableBalance(vestingAddr, utils.BaseDenom).IsZero())
+	require.True(t, coins.IsEqual(nw.GetAllBalances(vestingAddr)))
+
+	valAddr, err := sdktypes.ValAddressFromBech32(nw.GetValidators()[0].OperatorAddress)
+	require.NoError(t, err)
+	txBuilder := nw.app.GetTxConfig().NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(stakingtypes.NewMsgDelegate(vestingAddr, valAddr, coins[0])))
+
+	decorator := cosmosante.NewVestingDelegationDecorator(nw.app.AccountKeeper, nw.app.StakingKeeper, nw.app.BankKeeper, nw.app.AppCodec())
+	next := func(ctx sdktypes.Context, _ sdktypes.Tx, _ bool) (sdktypes.Context, error) { return ctx, nil }
+	_, err = decorator.AnteHandle(nw.GetContext(), txBuilder.GetTx(), false, next)
+	require.ErrorIs(t, err, vestingtypes.ErrInsufficientVestedCoins)
+}
+
+func TestDowntimeJailing(t *testing.T) {
+	nw := New(
+		WithSlashingWindow(10, sdktypes.NewDecWithPrec(5, 1)),
+	)
+	val := nw.valSet.Validators[0]
+	consAddr := sdktypes.ConsAddress(val.Address)
+	validator, found := nw.app.StakingKeeper.GetValidatorByConsAddr(nw.GetContext(), consAddr)
+	require.True(t, found)
+	tokens := validator.Tokens
+
+	_, err := nw.GetSigningInfo(consAddr)
+	require.Error(t, err)
+	require.NoError(t, nw.SignBlockWith([]string{val.Address.String()}))
+
+	require.NoError(t, nw.NextBlock())
+	signingInfo, err := nw.GetSigningInfo(consAddr)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), signingInfo.MissedBlocksCounter)
+
+	// The validator is jailed once the window has passed with more than half of the blocks missed
+	require.NoError(t, nw.CommitBlocks(11))
+	validator, found = nw.app.StakingKeeper.GetValidatorByConsAddr(nw.GetContext(), consAddr)
+	require.True(t, found)
+	require.True(t, validator.IsJailed())
+	require.True(t, validator.Tokens.LT(tokens))
+
+	signingInfo, err = nw.GetSigningInfo(consAddr)
+	require.NoError(t, err)
+	require.True(t, signingInfo.JailedUntil.After(nw.GetContext().BlockTime()))
+
+	require.Panics(t, func() {
+		New(WithSlashingWindow(0, sdktypes.NewDecWithPrec(5, 1)))
+	})
+}
+
+func TestDelegationRewards(t *testing.T) {
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 1e18))
+	rate := sdktypes.NewDecWithPrec(1, 1)
+	nw := New(
+		WithFeeCollectorBalance(coins),
+		WithValidatorCommissions(stakingtypes.NewCommissionRates(rate, rate, sdktypes.ZeroDec())),
+	)
+	validator := nw.GetValidators()[0]
+	valAddr, err := sdktypes.ValAddressFromBech32(validator.OperatorAddress)
+	require.NoError(t, err)
+	delegator := nw.app.StakingKeeper.GetValidatorDelegations(nw.GetContext(), valAddr)[0].DelegatorAddress
+
+	// The fees are allocated to the validators that signed the previous block
+	require.NoError(t, nw.SignBlockWith(nil))
+	require.NoError(t, nw.NextBlock())
+
+	rewards, err := nw.DelegationRewards(delegator, validator.OperatorAddress)
+	require.NoError(t, err)
+	require.True(t, rewards.AmountOf(utils.BaseDenom).IsPositive())
+
+	// The query doesn't modify the state
+	sameRewards, err := nw.DelegationRewards(delegator, validator.OperatorAddress)
+	require.NoError(t, err)
+	require.Equal(t, rewards, sameRewards)
+
+	commission, err := nw.ValidatorCommission(validator.OperatorAddress)
+	require.NoError(t, err)
+	require.True(t, commission.AmountOf(utils.BaseDenom).IsPositive())
+
+	_, err = nw.DelegationRewards(nw.cfg.preFundedAccounts[0].String(), sdktypes.ValAddress(nw.cfg.preFundedAccounts[0]).String())
+	require.Error(t, err)
+}
+
+func TestWithdrawAddress(t *testing.T) {
+	delegator, _ := testtx.NewAccAddressAndKey()
+	withdrawAddr, _ := testtx.NewAccAddressAndKey()
+	nw := New(
+		WithPreFundedAccounts(delegator, withdrawAddr),
+		WithWithdrawAddress(delegator, withdrawAddr),
+	)
+	require.Equal(t, withdrawAddr, nw.app.DistrKeeper.GetDelegatorWithdrawAddr(nw.GetContext(), delegator))
+
+	unfunded, _ := testtx.NewAccAddressAndKey()
+	require.Panics(t, func() {
+		New(
+			WithPreFundedAccounts(delegator),
+			WithWithdrawAddress(delegator, unfunded),
+		)
+	})
+}
+
+func TestInitialHeight(t *testing.T) {
+	nw := New(
+		WithInitialHeight(100),
+	)
+	// The genesis block is committed at the initial height
+	require.Equal(t, int64(100), nw.app.LastBlockHeight())
+	require.Equal(t, int64(101), nw.GetContext().BlockHeight())
+
+	require.NoError(t, nw.CommitBlocks(2))
+	require.Equal(t, int64(103), nw.GetContext().BlockHeight())
+
+	require.Panics(t, func() {
+		New(WithInitialHeight(0))
+	})
+}
+
+func TestTxResultHook(t *testing.T) {
+	type txResult struct {
+		height  int64
+		txIndex int
+		txBytes []byte
+	}
+	var results []txResult
+	nw := New(
+		WithTxResultHook(func(height int64, txIndex int, txBytes []byte, _ abcitypes.ResponseDeliverTx) {
+			results = append(results, txResult{height: height, txIndex: txIndex, txBytes: txBytes})
+		}),
+	)
+
+	sender := nw.cfg.preFundedAccounts[0]
+	txConfig := nw.app.GetTxConfig()
+	txs := make([][]byte, 0, 2)
+	for i := int64(1); i <= 2; i++ {
+		receiver, _ := testtx.NewAccAddressAndKey()
+		txBuilder := txConfig.NewTxBuilder()
+		coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, i))
+		require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(sender, receiver, coins)))
+		txBytes, err := txConfig.TxEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+		txs = append(txs, txBytes)
+	}
+
+	height := nw.GetContext().BlockHeight()
+	for _, txBytes := range txs {
+		_, err := nw.BroadcastTxSync(txBytes)
+		require.NoError(t, err)
+	}
+	// The results are passed once the block is committed
+	require.Empty(t, results)
+
+	require.NoError(t, nw.NextBlock())
+	require.Equal(t, []txResult{
+		{height: height, txIndex: 0, txBytes: txs[0]},
+		{height: height, txIndex: 1, txBytes: txs[1]},
+	}, results)
+
+	require.NoError(t, nw.NextBlock())
+	require.Len(t, results, 2)
+}
+
+func TestExpectNoStateChange(t *testing.T) {
+	nw := New()
+	sender := nw.cfg.preFundedAccounts[0]
+	receiver, _ := testtx.NewAccAddressAndKey()
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin(utils.BaseDenom, 1000))
+	bankMsgServer := bankkeeper.NewMsgServerImpl(nw.app.BankKeeper)
+	send := func() error {
+		_, err := bankMsgServer.Send(nw.GetContext(), banktypes.NewMsgSend(sender, receiver, coins))
+		return err
+	}
+
+	require.NoError(t, nw.ExpectNoStateChange(func() error {
+		nw.GetAllBalances(sender)
+		return nil
+	}))
+	require.ErrorContains(t, nw.ExpectNoStateChange(send), "balances changed")
+	require.NoError(t, nw.ExpectNoStateChange(send, ExcludeAccounts(sender, receiver)))
+}
+
+func TestMinGasPrices(t *testing.T) {
+	minGasPrices := sdktypes.NewDecCoins(sdktypes.NewDecCoinFromDec(utils.BaseDenom, sdktypes.NewDec(1e9)))
+	nw := New(
+		WithMinGasPrices(minGasPrices),
+	)
+	checkCtx := nw.app.BaseApp.NewContext(true, nw.GetContext().BlockHeader())
+	require.Equal(t, minGasPrices, checkCtx.MinGasPrices())
+
+	require.Panics(t, func() {
+		New(WithMinGasPrices(sdktypes.DecCoins{{Denom: utils.BaseDenom, Amount: sdktypes.NewDec(-1)}}))
+	})
+}
+
+func TestErc20Precompiles(t *testing.T) {
+	pair := NativeErc20Pair{Denom: "uatom", Name: "Atom", Symbol: "ATOM", Decimals: 6}
+	contractAddr, found := New(WithNativeErc20Pairs(pair)).Erc20ContractAddress(pair.Denom)
+	require.True(t, found)
+
+	nw := New(
+		WithNativeErc20Pairs(pair),
+		WithErc20Precompiles(contractAddr.Hex()),
+	)
+	require.True(t, nw.app.EvmKeeper.IsAvailablePrecompile(contractAddr))
+	require.Contains(t, nw.app.EvmKeeper.GetParams(nw.GetContext()).ActivePrecompiles, contractAddr.Hex())
+
+	require.Panics(t, func() {
+		New(WithNativeErc20Pairs(pair), WithErc20Precompiles("0x123"))
+	})
+	require.Panics(t, func() {
+		New(WithErc20Precompiles(contractAddr.Hex()))
+	})
+}
+
+func TestAppHashDeterminism(t *testing.T) {
+	genesisTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newNetwork := func(seed int64) *IntegrationNetwork {
+		return New(
+			WithGenesisTime(genesisTime),
+			WithDeterministicPreFundedAccounts(seed, 2),
+			WithDeterministicValidators(seed, 3),
+		)
+	}
+	nwA, nwB := newNetwork(1), newNetwork(1)
+	require.Equal(t, nwA.AppHash(), nwB.AppHash())
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, nwA.NextBlock())
+		require.NoError(t, nwB.NextBlock())
+		require.Equal(t, nwA.AppHash(), nwB.AppHash(), "app hash mismatch at height %d", nwA.app.LastBlockHeight())
+	}
+
+	require.NotEqual(t, newNetwork(1).AppHash(), newNetwork(2).AppHash())
+}
+
+func TestCreateModuleAccount(t *testing.T) {
+	testCases := []struct {
+		name       string
+		moduleName string
+		perms      []string
+		expPass    bool
+	}{
+		{"pass - no permissions", "newmodule", nil, true},
+		{"pass - minter and burner", "newmodule", []string{authtypes.Minter, authtypes.Burner}, true},
+		{"fail - app module account", distrtypes.ModuleName, nil, false},
+		{"fail - unknown permission", "newmodule", []string{"unknown"}, false},
+		{"fail - empty name", "", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			moduleAccount, err := createModuleAccount(tc.moduleName, tc.perms...)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, authtypes.NewModuleAddress(tc.moduleName), moduleAccount.GetAddress())
+			for _, perm := range tc.perms {
+				require.True(t, moduleAccount.HasPermission(perm))
+			}
+		})
+	}
+
+	nw := New(
+		WithModuleAccount("newmodule", authtypes.Burner),
+	)
+	acc := nw.app.AccountKeeper.GetAccount(nw.GetContext(), authtypes.NewModuleAddress("newmodule"))
+	moduleAccount, ok := acc.(authtypes.ModuleAccountI)
+	require.True(t, ok)
+	require.Equal(t, "newmodule", moduleAccount.GetName())
+	require.True(t, moduleAccount.HasPermission(authtypes.Burner))
+}
+
+func TestGetEVMAccount(t *testing.T) {
+	nw := New()
+	addr := common.BytesToAddress(nw.cfg.preFundedAccounts[0])
+	account, err := nw.GetEVMAccount(addr)
+	require.NoError(t, err)
+	require.Equal(t, PrefundedAccountInitialBalance.BigInt(), account.Balance)
+	require.Zero(t, account.Nonce)
+	require.False(t, account.IsContract())
+
+	unknown, _ := testtx.NewAddrKey()
+	account, err = nw.GetEVMAccount(unknown)
+	require.NoError(t, err)
+	require.Zero(t, account.Balance.Sign())
+	require.Zero(t, account.Nonce)
+	require.Equal(t, crypto.Keccak256(nil), account.CodeHash)
+}
+
+func TestDisabledModuleHooks(t *testing.T) {
+	nw := New(
+		WithDisabledModuleHooks(epochstypes.ModuleName),
+	)
+	mm := nw.app.GetModuleManager()
+	require.NotContains(t, mm.OrderBeginBlockers, epochstypes.ModuleName)
+	require.NotContains(t, mm.OrderEndBlockers, epochstypes.ModuleName)
+
+	require.NoError(t, nw.NextBlock())
+	epochInfo, found := nw.app.EpochsKeeper.GetEpochInfo(nw.GetContext(), epochstypes.DayEpochID)
+	require.True(t, found)
+	require.False(t, epochInfo.EpochCountingStarted)
+
+	defaultNw := New()
+	epochInfo, found = defaultNw.app.EpochsKeeper.GetEpochInfo(defaultNw.GetContext(), epochstypes.DayEpochID)
+	require.True(t, found)
+	require.True(t, epochInfo.EpochCountingStarted)
+
+	require.Panics(t, func() {
+		New(WithDisabledModuleHooks("unknown"))
+	})
+}
+
+func TestDepositPeriodProposals(t *testing.T) {
+	depositor, _ := testtx.NewAccAddressAndKey()
+	minDeposit := sdktypes.NewCoins(sdktypes.NewCoin(utils.BaseDenom, sdktypes.NewInt(1e18)))
+	deposit := sdktypes.NewCoins(sdktypes.NewCoin(utils.BaseDenom, sdktypes.NewInt(1e17)))
+
+	testCases := []struct {
+		name           string
+		burnDeposits   bool
+		expRefund      sdktypes.Coins
+		expSupplyDelta sdktypes.Coins
+	}{
+		{"refunded deposits", false, deposit, sdktypes.NewCoins()},
+		{"burned deposits", true, sdktypes.NewCoins(), deposit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := govv1types.DefaultParams()
+			params.MinDeposit = minDeposit
+			params.BurnProposalDepositPrevote = tc.burnDeposits
+
+			nw := New(
+				WithPreFundedAccounts(depositor),
+				WithGovParams(params),
+				WithDepositPeriodProposal(depositor, deposit, 0),
+				WithDepositPeriodProposal(depositor, deposit, time.Hour),
+			)
+			expiredProposal, found := nw.app.GovKeeper.GetProposal(nw.GetContext(), 1)
+			require.True(t, found)
+			require.Equal(t, govv1types.StatusDepositPeriod, expiredProposal.Status)
+			require.Equal(t, deposit.Add(deposit...), nw.GetAllBalances(authtypes.NewModuleAddress(govtypes.ModuleName)))
+
+			balanceBefore := nw.GetAllBalances(depositor)
+			supplyBefore := nw.TotalSupply()
+			require.NoError(t, nw.NextBlock())
+
+			_, found = nw.app.GovKeeper.GetProposal(nw.GetContext(), 1)
+			require.False(t, found)
+			activeProposal, found := nw.app.GovKeeper.GetProposal(nw.GetContext(), 2)
+			require.True(t, found)
+			require.Equal(t, govv1types.StatusDepositPeriod, activeProposal.Status)
+
+			require.Equal(t, balanceBefore.Add(tc.expRefund...), nw.GetAllBalances(depositor))
+			require.Equal(t, supplyBefore.Sub(tc.expSupplyDelta...), nw.TotalSupply())
+		})
+	}
+
+	params := govv1types.DefaultParams()
+	params.MinDeposit = minDeposit
+	require.Panics(t, func() {
+		New(WithPreFundedAccounts(depositor), WithGovParams(params), WithDepositPeriodProposal(depositor, minDeposit, 0))
+	})
+	require.Panics(t, func() {
+		New(WithPreFundedAccounts(depositor), WithGovParams(params), WithDepositPeriodProposal(depositor, sdktypes.NewCoins(sdktypes.NewInt64Coin(ibcDenom, 1)), 0))
+	})
+	require.Panics(t, func() {
+		New(WithPreFundedAccounts(depositor), WithGovParams(params), WithDepositPeriodProposal(depositor, deposit, *params.MaxDepositPeriod+time.Second))
+	})
+}
+
+func TestNextBlockAfterEpochBoundaries(t *testing.T) {
+	nw := New()
+	dayEpoch, found := nw.app.EpochsKeeper.GetEpochInfo(nw.GetContext(), epochstypes.DayEpochID)
+	require.True(t, found)
+	require.True(t, dayEpoch.EpochCountingStarted)
+
+	startHeight := nw.GetContext().BlockHeight()
+	startTime := nw.GetContext().BlockTime()
+
+	// The day epoch ends after 24h and 48h, so only the first end needs an intermediate block
+	height, blockTime, err := nw.NextBlockAfter(72 * time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, startHeight+2, height)
+	require.Equal(t, startTime.Add(72*time.Hour), blockTime)
+
+	newDayEpoch, found := nw.app.EpochsKeeper.GetEpochInfo(nw.GetContext(), epochstypes.DayEpochID)
+	require.True(t, found)
+	require.Equal(t, dayEpoch.CurrentEpoch+2, newDayEpoch.CurrentEpoch)
+
+	for h := startHeight + 1; h <= height; h++ {
+		events, err := nw.EventsByType(h, epochstypes.EventTypeEpochEnd)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+	}
+
+	// A single boundary is crossed on the new block without intermediate blocks
+	height, _, err = nw.NextBlockAfter(24 * time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, startHeight+3, height)
+
+	events, err := nw.EventsByType(height, epochstypes.EventTypeEpochEnd)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	newDayEpoch, found = nw.app.EpochsKeeper.GetEpochInfo(nw.GetContext(), epochstypes.DayEpochID)
+	require.True(t, found)
+	require.Equal(t, dayEpoch.CurrentEpoch+3, newDayEpoch.CurrentEpoch)
+}
+
+func TestDeterministicAccountsSignEthTx(t *testing.T) {
+	accounts, privKeys := DeterministicAccounts(7, 2)
+	nw := New(
+		WithDeterministicPreFundedAccounts(7, 2),
+	)
+	require.Equal(t, accounts, nw.cfg.preFundedAccounts)
+
+	receiver := common.BytesToAddress(accounts[1])
+	msg := evmtypes.NewTx(&evmtypes.EvmTxArgs{
+		ChainID:  nw.GetEIP155ChainID(),
+		To:       &receiver,
+		Amount:   big.NewInt(1000),
+		GasLimit: 21000,
+	})
+	msg.From = common.BytesToAddress(accounts[0]).String()
+
+	// The signer recovered from the signed tx is the pre-funded account
+	signer := ethtypes.LatestSignerForChainID(nw.GetEIP155ChainID())
+	require.NoError(t, msg.Sign(signer, testtx.NewSigner(privKeys[0])))
+	require.NoError(t, msg.ValidateBasic())
+	sender, err := ethtypes.Sender(signer, msg.AsTransaction())
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToAddress(accounts[0]), sender)
+}
+
+func TestInflationGenesisState(t *testing.T) {
+	nw := New()
+	params := nw.app.InflationKeeper.GetParams(nw.GetContext())
+	require.False(t, params.EnableInflation)
+
+	customGenesis := inflationtypes.DefaultGenesisState()
+	customGenesis.Period = 5
+	customGenesis.SkippedEpochs = 2
+	customGenesis.Params.MintDenom = utils.BaseDenom
+	nw = New(
+		WithCustomGenesis(CustomGenesisState{inflationtypes.ModuleName: customGenesis}),
+		WithInflationEnabled(epochstypes.WeekEpochID, 52),
+	)
+
+	ctx := nw.GetContext()
+	params = nw.app.InflationKeeper.GetParams(ctx)
+	require.True(t, params.EnableInflation)
+	require.Equal(t, uint64(5), nw.app.InflationKeeper.GetPeriod(ctx))
+	require.Equal(t, uint64(2), nw.app.InflationKeeper.GetSkippedEpochs(ctx))
+	require.Equal(t, epochstypes.WeekEpochID, nw.app.InflationKeeper.GetEpochIdentifier(ctx))
+	require.Equal(t, int64(52), nw.app.InflationKeeper.GetEpochsPerPeriod(ctx))
+
+	require.Panics(t, func() {
+		New(WithInflationEnabled("unknown", 1))
+	})
+}
+
+func TestJailValidator(t *testing.T) {
+	nw := New()
+	valAddr := nw.GetValidators()[0].OperatorAddress
+	consAddr, err := nw.GetValidators()[0].GetConsAddr()
+	require.NoError(t, err)
+
+	// The validator update takes effect on the block after the next one
+	require.NoError(t, nw.JailValidator(valAddr))
+	require.True(t, nw.GetValidators()[0].IsJailed())
+	require.True(t, nw.valSet.HasAddress(consAddr))
+	require.False(t, nw.nextValSet.HasAddress(consAddr))
+	require.NoError(t, nw.NextBlock())
+	require.False(t, nw.valSet.HasAddress(consAddr))
+
+	require.Error(t, nw.UnjailValidator(valAddr))
+	_, _, err = nw.NextBlockAfter(nw.app.SlashingKeeper.DowntimeJailDuration(nw.GetContext()))
+	require.NoError(t, err)
+
+	require.NoError(t, nw.UnjailValidator(valAddr))
+	require.False(t, nw.GetValidators()[0].IsJailed())
+	require.False(t, nw.valSet.HasAddress(consAddr))
+	require.True(t, nw.nextValSet.HasAddress(consAddr))
+	require.NoError(t, nw.NextBlock())
+	require.True(t, nw.valSet.HasAddress(consAddr))
+}
+
+func TestCloseRemovesHomePath(t *testing.T) {
+	nw := New()
+	homePath := nw.cfg.homePath
+	require.DirExists(t, homePath)
+	require.NoError(t, nw.Close())
+	_, err := os.Stat(homePath)
+	require.True(t, os.IsNotExist(err))
+	// closing the network twice is a no-op
+	require.NoError(t, nw.Close())
+
+	// a custom home path is kept
+	customPath := t.TempDir()
+	nw = New(WithHomePath(customPath))
+	require.NoError(t, nw.Close())
+	require.DirExists(t, customPath)
+}
+
+func TestNetworkFromGenesisFile(t *testing.T) {
+	genesisState, err := New().ExportGenesis()
+	require.NoError(t, err)
+	appState, err := json.Marshal(genesisState)
+	require.NoError(t, err)
+	genDoc := tmtypes.GenesisDoc{
+		ChainID:     utils.TestnetChainID + "-1",
+		GenesisTime: time.Now().UTC(),
+		AppState:    appState,
+	}
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(t, genDoc.SaveAs(path))
+
+	minGasPrices := sdktypes.NewDecCoins(sdktypes.NewDecCoinFromDec(utils.BaseDenom, sdktypes.NewDec(1e9)))
+	nw := NewNetworkFromGenesisFile(
+		path,
+		WithMinGasPrices(minGasPrices),
+		WithUpgradePlan(upgradetypes.Plan{Name: "test-upgrade", Height: 5}, nil),
+	)
+	defer nw.Close()
+	require.Equal(t, utils.MainnetChainID+"-1", nw.GetChainID())
+	checkCtx := nw.app.BaseApp.NewContext(true, nw.GetContext().BlockHeader())
+	require.Equal(t, minGasPrices, checkCtx.MinGasPrices())
+	plan, found := nw.app.UpgradeKeeper.GetUpgradePlan(nw.GetContext())
+	require.True(t, found)
+	require.Equal(t, "test-upgrade", plan.Name)
+
+	// the options that build the genesis state conflict with the genesis file
+	require.PanicsWithError(t, "WithAmountOfValidators is not supported when the network is created from a genesis file, use a custom genesis state instead", func() {
+		NewNetworkFromGenesisFile(path, WithAmountOfValidators(5))
+	})
+}
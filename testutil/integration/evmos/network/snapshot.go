@@ -0,0 +1,199 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// SnapshotID identifies a network state captured with Snapshot.
+type SnapshotID int
+
+// networkSnapshot holds a copy of the network's committed state together
+// with the context of the block in progress when the snapshot was taken.
+type networkSnapshot struct {
+	db         *dbm.MemDB
+	header     tmproto.Header
+	ctx        sdktypes.Context
+	valSet     *tmtypes.ValidatorSet
+	nextValSet *tmtypes.ValidatorSet
+}
+
+// Snapshot captures the current network state and returns its identifier.
+// The current block is committed first, so that all the state changes made
+// so far are included in the snapshot.
+func (n *IntegrationNetwork) Snapshot() (SnapshotID, error) {
+	if err := n.NextBlock(); err != nil {
+		return 0, fmt.Errorf("failed to commit block before snapshot: %w", err)
+	}
+
+	db, err := copyMemDB(n.db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy network database: %w", err)
+	}
+
+	n.snapshots = append(n.snapshots, networkSnapshot{
+		db:         db,
+		header:     n.ctx.BlockHeader(),
+		ctx:        n.ctx,
+		valSet:     n.valSet.Copy(),
+		nextValSet: n.nextValSet.Copy(),
+	})
+	return SnapshotID(len(n.snapshots) - 1), nil
+}
+
+// RestoreSnapshot resets the network state to the one captured with the given
+// snapshot. A new app is loaded from a copy of the snapshot database, so that
+// the same snapshot can be restored multiple times.
+func (n *IntegrationNetwork) RestoreSnapshot(id SnapshotID) error {
+	if id < 0 || int(id) >= len(n.snapshots) {
+		return fmt.Errorf("snapshot %d not found", id)
+	}
+	snapshot := n.snapshots[id]
+
+	db, err := copyMemDB(snapshot.db)
+	if err != nil {
+		return fmt.Errorf("failed to copy snapshot database: %w", err)
+	}
+	if err := n.loadState(db, snapshot.header, snapshot.ctx); err != nil {
+		return err
+	}
+
+	// The validator sets are copied, as they change on every block
+	n.valSet = snapshot.valSet.Copy()
+	n.nextValSet = snapshot.nextValSet.Copy()
+	n.refreshValidators()
+	return nil
+}
+
+// Clone returns a new network with a copy of the current network state. The current
+// block is committed first, so that all the state changes made so far are included.
+// The clone and the original network don't share any state, so they can be used
+// concurrently, e.g. on parallel subtests. The clones must be created before the
+// networks are used concurrently.
+//
+// NOTE: the custom precompiles are shared with the clone, so the ones holding
+// references to the original app's keepers still operate on the original state.
+func (n *IntegrationNetwork) Clone() (*IntegrationNetwork, error) {
+	if err := n.NextBlock(); err != nil {
+		return nil, fmt.Errorf("failed to commit block before clone: %w", err)
+	}
+
+	db, err := copyMemDB(n.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy network database: %w", err)
+	}
+
+	valSigners := make(map[string]tmtypes.PrivValidator, len(n.valSigners))
+	for addr, signer := range n.valSigners {
+		valSigners[addr] = signer
+	}
+	var absentSigners map[string]bool
+	if n.absentSigners != nil {
+		absentSigners = make(map[string]bool, len(n.absentSigners))
+		for addr, absent := range n.absentSigners {
+			absentSigners[addr] = absent
+		}
+	}
+
+	clone := &IntegrationNetwork{
+		cfg:                n.cfg,
+		validators:         append([]stakingtypes.Validator{}, n.validators...),
+		valSet:             n.valSet.Copy(),
+		nextValSet:         n.nextValSet.Copy(),
+		valSigners:         valSigners,
+		genesisTotalSupply: n.genesisTotalSupply,
+		absentSigners:      absentSigners,
+		minGasPrices:       n.minGasPrices,
+	}
+	if err := clone.loadState(db, n.ctx.BlockHeader(), n.ctx); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// loadState loads a new app from the given database and begins the block with the
+// given header. The context of the new block keeps the configuration of the given context.
+func (n *IntegrationNetwork) loadState(db *dbm.MemDB, header tmproto.Header, ctx sdktypes.Context) error {
+	evmosApp := createEvmosApp(n.cfg.chainID, n.cfg.homePath, n.cfg.blockedAddresses, db, n.cfg.logger, n.cfg.anteHandler, baseapp.SetMinGasPrices(n.minGasPrices.String()))
+	if evmosApp.LastBlockHeight() != header.Height-1 {
+		return fmt.Errorf("unexpected state height: expected %d, got %d", header.Height-1, evmosApp.LastBlockHeight())
+	}
+	if err := disableModuleHooks(evmosApp, n.cfg.disabledModuleHooks); err != nil {
+		return err
+	}
+	// The upgrade handlers are kept in memory, so the one of the scheduled plan is registered
+	// again before the BeginBlock, which applies it at the plan height
+	if n.cfg.upgradePlan != nil && n.cfg.upgradeHandler != nil {
+		evmosApp.UpgradeKeeper.SetUpgradeHandler(n.cfg.upgradePlan.Name, n.cfg.upgradeHandler)
+	}
+	beginBlockRes := evmosApp.BeginBlock(abcitypes.RequestBeginBlock{Header: header})
+
+	// Update context header
+	newCtx := evmosApp.BaseApp.NewContext(false, header)
+	newCtx = newCtx.WithMinGasPrices(ctx.MinGasPrices())
+	newCtx = newCtx.WithKVGasConfig(ctx.KVGasConfig())
+	newCtx = newCtx.WithTransientKVGasConfig(ctx.TransientKVGasConfig())
+	newCtx = newCtx.WithConsensusParams(ctx.ConsensusParams())
+	newCtx = newCtx.WithBlockGasMeter(sdktypes.NewInfiniteGasMeter())
+
+	// The custom and ERC20 precompiles are kept in memory, so they are added to the new app
+	if err := addCustomPrecompiles(newCtx, evmosApp, n.cfg.customPrecompiles); err != nil {
+		return err
+	}
+	erc20Precompiles, err := createErc20Precompiles(newCtx, evmosApp, n.cfg.erc20Precompiles)
+	if err != nil {
+		return err
+	}
+	if err := addCustomPrecompiles(newCtx, evmosApp, erc20Precompiles); err != nil {
+		return err
+	}
+
+	n.app = evmosApp
+	n.db = db
+	n.ctx = newCtx
+
+	// The events of the previous blocks are not part of the loaded state
+	n.blockEvents = nil
+	n.blockTxs = nil
+	n.recordBlockEvents(header.Height, beginBlockRes.Events)
+	return nil
+}
+
+// RestoreSnapshot resets the network state to the one captured with the given
+// snapshot and updates the public app reference.
+func (n *UnitTestNetwork) RestoreSnapshot(id SnapshotID) error {
+	if err := n.IntegrationNetwork.RestoreSnapshot(id); err != nil {
+		return err
+	}
+	n.App = n.app
+	return nil
+}
+
+// copyMemDB returns an in-memory database holding a copy of all
+// the key-value pairs in the given database.
+func copyMemDB(db dbm.DB) (*dbm.MemDB, error) {
+	iterator, err := db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	dbCopy := dbm.NewMemDB()
+	for ; iterator.Valid(); iterator.Next() {
+		key := append([]byte{}, iterator.Key()...)
+		value := append([]byte{}, iterator.Value()...)
+		if err := dbCopy.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return dbCopy, iterator.Error()
+}
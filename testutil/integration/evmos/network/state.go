@@ -0,0 +1,112 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	evmtypes "github.com/evmos/evmos/v16/x/evm/types"
+)
+
+// stateChangeConfig defines which parts of the state are compared by ExpectNoStateChange
+type stateChangeConfig struct {
+	excludedAccounts map[string]bool
+}
+
+// StateChangeOption defines a function that modifies which parts of the state are compared
+type StateChangeOption func(*stateChangeConfig)
+
+// ExcludeAccounts excludes the balances and nonces of the given accounts from the state
+// comparison, e.g. the sender of a reverted transaction and the fee collector, as a
+// delivered transaction always charges the fees and increments the sender nonce.
+func ExcludeAccounts(addrs ...sdktypes.AccAddress) StateChangeOption {
+	return func(cfg *stateChangeConfig) {
+		for _, addr := range addrs {
+			cfg.excludedAccounts[addr.String()] = true
+		}
+	}
+}
+
+// stateFingerprint holds the hashes of the parts of the state compared by ExpectNoStateChange
+type stateFingerprint struct {
+	evmStorage []byte
+	balances   []byte
+	nonces     []byte
+}
+
+// ExpectNoStateChange runs the given action and checks that the EVM storage and code, the
+// balances and the account nonces of the current state are the same after it, e.g. for a
+// read-only precompile method or a reverted EVM transaction. The action should not commit
+// blocks, as the block rewards change the balances.
+// It returns an error if the action fails or if any of those parts of the state changed.
+//
+// NOTE: the state is fingerprinted by iterating over all the EVM storage, balances and
+// accounts before and after the action, so its cost grows with the size of the state.
+func (n *IntegrationNetwork) ExpectNoStateChange(action func() error, opts ...StateChangeOption) error {
+	cfg := stateChangeConfig{excludedAccounts: map[string]bool{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	before := n.stateFingerprint(cfg)
+	if err := action(); err != nil {
+		return fmt.Errorf("failed to run action: %w", err)
+	}
+	after := n.stateFingerprint(cfg)
+
+	switch {
+	case !bytes.Equal(before.evmStorage, after.evmStorage):
+		return fmt.Errorf("unexpected state change: the EVM storage changed")
+	case !bytes.Equal(before.balances, after.balances):
+		return fmt.Errorf("unexpected state change: the balances changed")
+	case !bytes.Equal(before.nonces, after.nonces):
+		return fmt.Errorf("unexpected state change: the account nonces changed")
+	}
+	return nil
+}
+
+// stateFingerprint returns the hashes of the EVM storage and code, the balances and the
+// nonces of the current state, skipping the balances and nonces of the excluded accounts.
+func (n *IntegrationNetwork) stateFingerprint(cfg stateChangeConfig) stateFingerprint {
+	evmStore := n.ctx.KVStore(n.app.GetKey(evmtypes.StoreKey))
+	storageHash := sha256.New()
+	for _, keyPrefix := range [][]byte{evmtypes.KeyPrefixCode, evmtypes.KeyPrefixStorage} {
+		iterator := prefix.NewStore(evmStore, keyPrefix).Iterator(nil, nil)
+		for ; iterator.Valid(); iterator.Next() {
+			storageHash.Write(keyPrefix)
+			storageHash.Write(iterator.Key())
+			storageHash.Write(iterator.Value())
+		}
+		iterator.Close()
+	}
+
+	balancesHash := sha256.New()
+	n.app.BankKeeper.IterateAllBalances(n.ctx, func(addr sdktypes.AccAddress, coin sdktypes.Coin) bool {
+		if !cfg.excludedAccounts[addr.String()] {
+			balancesHash.Write(addr)
+			balancesHash.Write([]byte(coin.String()))
+		}
+		return false
+	})
+
+	noncesHash := sha256.New()
+	n.app.AccountKeeper.IterateAccounts(n.ctx, func(account authtypes.AccountI) bool {
+		if !cfg.excludedAccounts[account.GetAddress().String()] {
+			noncesHash.Write(account.GetAddress())
+			noncesHash.Write(binary.BigEndian.AppendUint64(nil, account.GetSequence()))
+		}
+		return false
+	})
+
+	return stateFingerprint{
+		evmStorage: storageHash.Sum(nil),
+		balances:   balancesHash.Sum(nil),
+		nonces:     noncesHash.Sum(nil),
+	}
+}
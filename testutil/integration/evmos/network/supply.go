@@ -0,0 +1,88 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/evmos/evmos/v16/app"
+)
+
+// GetGenesisTotalSupply returns the total supply of the network at genesis,
+// calculated from the genesis balances.
+func (n *IntegrationNetwork) GetGenesisTotalSupply() sdktypes.Coins {
+	return n.genesisTotalSupply
+}
+
+// TotalSupply returns the current total supply of the network
+func (n *IntegrationNetwork) TotalSupply() sdktypes.Coins {
+	supply := sdktypes.NewCoins()
+	n.app.BankKeeper.IterateTotalSupply(n.ctx, func(coin sdktypes.Coin) bool {
+		supply = supply.Add(coin)
+		return false
+	})
+	return supply
+}
+
+// AssertTotalSupply returns an error if the current total supply of the network
+// differs from the expected one.
+func (n *IntegrationNetwork) AssertTotalSupply(expected sdktypes.Coins) error {
+	supply := n.TotalSupply()
+	// NOTE: IsEqual can panic on differing denoms, so use (a == b) <=> (a >= b && b >= a)
+	if !supply.IsAllGTE(expected) || !expected.IsAllGTE(supply) {
+		return fmt.Errorf("total supply mismatch: expected %s, got %s", expected, supply)
+	}
+	return nil
+}
+
+// MintCoins mints the given coins to the module account with the given name and commits
+// the block. The total supply returned by TotalSupply includes the minted coins.
+// It returns an error if the coins are invalid or if the module account doesn't have
+// minter permissions.
+func (n *IntegrationNetwork) MintCoins(moduleName string, coins sdktypes.Coins) error {
+	if err := n.validateSupplyChange(moduleName, authtypes.Minter, coins); err != nil {
+		return err
+	}
+	if err := n.app.BankKeeper.MintCoins(n.ctx, moduleName, coins); err != nil {
+		return fmt.Errorf("failed to mint %s to module %s: %w", coins, moduleName, err)
+	}
+	return n.NextBlock()
+}
+
+// BurnCoins burns the given coins from the module account with the given name and commits
+// the block. The total supply returned by TotalSupply excludes the burned coins.
+// It returns an error if the coins are invalid, if the module account doesn't have burner
+// permissions or if its balance is lower than the coins to burn.
+func (n *IntegrationNetwork) BurnCoins(moduleName string, coins sdktypes.Coins) error {
+	if err := n.validateSupplyChange(moduleName, authtypes.Burner, coins); err != nil {
+		return err
+	}
+	if err := n.app.BankKeeper.BurnCoins(n.ctx, moduleName, coins); err != nil {
+		return fmt.Errorf("failed to burn %s from module %s: %w", coins, moduleName, err)
+	}
+	return n.NextBlock()
+}
+
+// validateSupplyChange checks that the given coins are valid and that the module account
+// with the given name has the given permission.
+func (n *IntegrationNetwork) validateSupplyChange(moduleName, permission string, coins sdktypes.Coins) error {
+	if err := coins.Validate(); err != nil {
+		return fmt.Errorf("invalid coins %s: %w", coins, err)
+	}
+	if coins.IsZero() {
+		return fmt.Errorf("coins cannot be empty")
+	}
+
+	permissions, found := app.GetMaccPerms()[moduleName]
+	if !found {
+		return fmt.Errorf("%w: %s", ErrUnknownModule, moduleName)
+	}
+	for _, p := range permissions {
+		if p == permission {
+			return nil
+		}
+	}
+	return fmt.Errorf("module account %s doesn't have %s permissions", moduleName, permission)
+}
@@ -0,0 +1,49 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package network
+
+import (
+	"errors"
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/evmos/evmos/v16/app"
+)
+
+// ErrUpgradeNeeded is returned when the network reaches the height of an upgrade plan
+// that has no registered handler, in the same way as a node halts without the new binary.
+var ErrUpgradeNeeded = errors.New("upgrade needed")
+
+// scheduleUpgradePlan registers the given handler, if any, for the plan and schedules
+// the plan on the upgrade keeper. The plan height must be after the genesis block.
+//
+// NOTE: the upgrade genesis doesn't hold the scheduled plan, so it is set once the app
+// is initialized.
+func scheduleUpgradePlan(ctx sdktypes.Context, evmosApp *app.Evmos, plan upgradetypes.Plan, handler upgradetypes.UpgradeHandler) error {
+	if err := plan.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid upgrade plan: %w", err)
+	}
+	if plan.Height <= ctx.BlockHeight() {
+		return fmt.Errorf("upgrade plan height %d must be after the genesis block height %d", plan.Height, ctx.BlockHeight())
+	}
+
+	if handler != nil {
+		evmosApp.UpgradeKeeper.SetUpgradeHandler(plan.Name, handler)
+	}
+	return evmosApp.UpgradeKeeper.ScheduleUpgrade(ctx, plan)
+}
+
+// checkUpgradeHalt returns ErrUpgradeNeeded if the next block reaches the height of the
+// scheduled upgrade plan and the plan has no registered handler. Otherwise, the upgrade
+// module applies the handler on the BeginBlock of the plan height.
+func (n *IntegrationNetwork) checkUpgradeHalt() error {
+	plan, found := n.app.UpgradeKeeper.GetUpgradePlan(n.ctx)
+	if !found || plan.Height > n.ctx.BlockHeight()+1 {
+		return nil
+	}
+	if n.app.UpgradeKeeper.HasHandler(plan.Name) {
+		return nil
+	}
+	return fmt.Errorf("%w: plan %q at height %d", ErrUpgradeNeeded, plan.Name, plan.Height)
+}
@@ -81,7 +81,7 @@ func RegisterERC20(tf factory.TxFactory, network network.Network, data ERC20Regi
 		return erc20types.TokenPair{}, errorsmod.Wrap(err, "failed to query voting params")
 	}
 
-	err = network.NextBlockAfter(*params.Params.VotingPeriod) // commit after voting period is over
+	_, _, err = network.NextBlockAfter(*params.Params.VotingPeriod) // commit after voting period is over
 	if err != nil {
 		return erc20types.TokenPair{}, errorsmod.Wrap(err, "failed to commit block after voting period ends")
 	}
@@ -113,28 +113,18 @@ func (c *IntegrationCoordinator) SetDefaultSignerForChain(chainID string, priv c
 // fail if any error occurs. The clientID's, TestConnections, and TestChannels are returned
 // for both chains. The channels created are connected to the ibc-transfer application.
 func (c *IntegrationCoordinator) Setup(a, b string) IBCConnection {
+	return newIBCConnection(a, b, c.setupTransferPath(a, b))
+}
+
+// setupTransferPath constructs a TM client, connection, and transfer channel on both
+// chains provided and returns the path between them.
+func (c *IntegrationCoordinator) setupTransferPath(a, b string) *evmosibc.Path {
 	chainA := c.coord.GetChain(a)
 	chainB := c.coord.GetChain(b)
 
 	path := evmosibc.NewTransferPath(chainA, chainB)
 	evmosibc.SetupPath(c.coord, path)
-
-	return IBCConnection{
-		EndpointA: Endpoint{
-			ChainID:      a,
-			ClientID:     path.EndpointA.ClientID,
-			ConnectionID: path.EndpointA.ConnectionID,
-			ChannelID:    path.EndpointA.ChannelID,
-			PortID:       path.EndpointA.ChannelConfig.PortID,
-		},
-		EndpointB: Endpoint{
-			ChainID:      b,
-			ClientID:     path.EndpointB.ClientID,
-			ConnectionID: path.EndpointB.ConnectionID,
-			ChannelID:    path.EndpointB.ChannelID,
-			PortID:       path.EndpointB.ChannelConfig.PortID,
-		},
-	}
+	return path
 }
 
 // CommitNBlocks commits n blocks on the chain with the given chainID.
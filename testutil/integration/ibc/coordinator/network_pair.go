@@ -0,0 +1,229 @@
+// Copyright Tharsis Labs Ltd.(Evmos)
+// SPDX-License-Identifier:ENCL-1.0(https://github.com/evmos/evmos/blob/main/LICENSE)
+package coordinator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	ibctesting "github.com/cosmos/ibc-go/v7/testing"
+	"github.com/evmos/evmos/v16/app"
+	evmosibc "github.com/evmos/evmos/v16/ibc/testing"
+	commongrpc "github.com/evmos/evmos/v16/testutil/integration/common/grpc"
+	commonnetwork "github.com/evmos/evmos/v16/testutil/integration/common/network"
+	"github.com/evmos/evmos/v16/testutil/integration/evmos/keyring"
+	"github.com/evmos/evmos/v16/testutil/integration/evmos/network"
+)
+
+// NetworkPair defines two Evmos integration networks connected through an ICS-20
+// transfer channel, with a minimal in-process relayer that moves the transfer packets
+// sent between them.
+//
+// NOTE: the transactions and blocks of both networks must go through the pair, so that
+// the IBC clients are updated with the headers of the committed blocks and the sent
+// packets are queued for relaying.
+type NetworkPair struct {
+	// KeyringA and KeyringB hold the funded account used to sign the transactions
+	// of each network.
+	KeyringA keyring.Keyring
+	KeyringB keyring.Keyring
+	// Connection holds the identifiers of the transfer channel between the networks.
+	Connection IBCConnection
+
+	networkA       *network.IntegrationNetwork
+	networkB       *network.IntegrationNetwork
+	coord          *IntegrationCoordinator
+	path           *evmosibc.Path
+	pendingPackets []channeltypes.Packet
+}
+
+// NewNetworkPair creates two independent Evmos integration networks with the given chain
+// IDs and opens a transfer channel between them. The given options are applied to both
+// networks, before their chain ID and funded accounts are set.
+//
+// It fails the test if the networks cannot be connected.
+func NewNetworkPair(t *testing.T, chainA, chainB string, opts ...network.ConfigOption) *NetworkPair {
+	keyringA, keyringB := keyring.New(1), keyring.New(1)
+	networkA := newPairNetwork(chainA, keyringA, opts)
+	networkB := newPairNetwork(chainB, keyringB, opts)
+
+	pair := &NetworkPair{
+		KeyringA: keyringA,
+		KeyringB: keyringB,
+		networkA: networkA,
+		networkB: networkB,
+		coord:    NewIntegrationCoordinator(t, []commonnetwork.Network{networkA, networkB}),
+	}
+	pair.setDefaultSigner(t, networkA, keyringA)
+	pair.setDefaultSigner(t, networkB, keyringB)
+
+	pair.path = pair.coord.setupTransferPath(chainA, chainB)
+	pair.Connection = newIBCConnection(chainA, chainB, pair.path)
+	return pair
+}
+
+// newPairNetwork creates a network with the given chain ID that funds the keyring accounts
+func newPairNetwork(chainID string, kr keyring.Keyring, opts []network.ConfigOption) *network.IntegrationNetwork {
+	networkOpts := append([]network.ConfigOption{}, opts...)
+	networkOpts = append(networkOpts,
+		network.WithChainID(chainID),
+		network.WithPreFundedAccounts(kr.GetAllAccAddrs()...),
+	)
+	return network.New(networkOpts...)
+}
+
+// setDefaultSigner sets the first keyring account as the signer of the network's IBC chain
+func (p *NetworkPair) setDefaultSigner(t *testing.T, nw *network.IntegrationNetwork, kr keyring.Keyring) {
+	acc, err := commongrpc.NewIntegrationHandler(nw).GetAccount(kr.GetAccAddr(0).String())
+	if err != nil {
+		t.Fatalf("failed to get signer account of %s: %v", nw.GetChainID(), err)
+	}
+	p.coord.SetDefaultSignerForChain(nw.GetChainID(), kr.GetPrivKey(0), acc)
+}
+
+// Networks returns the networks of the pair in the order of their chain IDs on creation.
+//
+// NOTE: the networks are not kept in sync with the blocks committed through the pair,
+// so their context must not be used after the pair commits a block. Use GetBalance to
+// read the balances of the current block instead.
+func (p *NetworkPair) Networks() (*network.IntegrationNetwork, *network.IntegrationNetwork) {
+	return p.networkA, p.networkB
+}
+
+// SendMsgs signs the given messages with the default signer of the network with the given
+// chain ID, delivers them and commits the block. The transfer packets sent on the pair's
+// channel are queued to be relayed with RelayPackets.
+func (p *NetworkPair) SendMsgs(chainID string, msgs ...sdktypes.Msg) (*sdktypes.Result, error) {
+	chain, err := p.getChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := evmosibc.SendMsgs(chain, ibctesting.DefaultFeeAmt, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	packets, err := parseSendPackets(res.Events)
+	if err != nil {
+		return nil, err
+	}
+	for _, packet := range packets {
+		if p.isTransferPacket(packet) {
+			p.pendingPackets = append(p.pendingPackets, packet)
+		}
+	}
+	return res, nil
+}
+
+// GetBalance returns the balance of the address for the given denomination on the
+// current block of the network with the given chain ID.
+func (p *NetworkPair) GetBalance(chainID string, addr sdktypes.AccAddress, denom string) (sdktypes.Coin, error) {
+	chain, err := p.getChain(chainID)
+	if err != nil {
+		return sdktypes.Coin{}, err
+	}
+	evmosApp, ok := chain.App.(*app.Evmos)
+	if !ok {
+		return sdktypes.Coin{}, fmt.Errorf("chain %s is not an Evmos network", chainID)
+	}
+	return evmosApp.BankKeeper.GetBalance(chain.GetContext(), addr, denom), nil
+}
+
+// RelayPackets relays the queued transfer packets in the order they were sent. Each
+// packet is received on the counterparty network and its acknowledgement is relayed
+// back to the source network.
+// It returns an error if any relay step fails. The packets that were not relayed remain queued.
+func (p *NetworkPair) RelayPackets() error {
+	for len(p.pendingPackets) > 0 {
+		packet := p.pendingPackets[0]
+		if err := p.path.RelayPacket(packet); err != nil {
+			return fmt.Errorf("failed to relay packet %d from %s/%s: %w", packet.Sequence, packet.SourcePort, packet.SourceChannel, err)
+		}
+		p.pendingPackets = p.pendingPackets[1:]
+	}
+	return nil
+}
+
+// PendingPackets returns the transfer packets that are queued to be relayed
+func (p *NetworkPair) PendingPackets() []channeltypes.Packet {
+	return p.pendingPackets
+}
+
+// CommitBlocks commits n blocks on both networks, first on network A and then on network B
+func (p *NetworkPair) CommitBlocks(n uint64) error {
+	for _, chainID := range []string{p.networkA.GetChainID(), p.networkB.GetChainID()} {
+		if err := p.coord.CommitNBlocks(chainID, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getChain returns the IBC test chain of the network with the given chain ID
+func (p *NetworkPair) getChain(chainID string) (*ibctesting.TestChain, error) {
+	if chainID != p.networkA.GetChainID() && chainID != p.networkB.GetChainID() {
+		return nil, fmt.Errorf("chain %s is not part of the network pair", chainID)
+	}
+	return p.coord.coord.GetChain(chainID), nil
+}
+
+// isTransferPacket returns true if the packet was sent on either end of the pair's transfer channel
+func (p *NetworkPair) isTransferPacket(packet channeltypes.Packet) bool {
+	for _, endpoint := range []*evmosibc.Endpoint{p.path.EndpointA, p.path.EndpointB} {
+		if packet.SourcePort == endpoint.ChannelConfig.PortID && packet.SourceChannel == endpoint.ChannelID {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSendPackets returns the packets of the send packet events among the given events
+func parseSendPackets(events []abcitypes.Event) ([]channeltypes.Packet, error) {
+	var packets []channeltypes.Packet
+	for _, event := range events {
+		if event.Type != channeltypes.EventTypeSendPacket {
+			continue
+		}
+
+		attributes := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attributes[attr.Key] = attr.Value
+		}
+
+		data, err := hex.DecodeString(attributes[channeltypes.AttributeKeyDataHex])
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet data: %w", err)
+		}
+		sequence, err := strconv.ParseUint(attributes[channeltypes.AttributeKeySequence], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet sequence: %w", err)
+		}
+		timeoutHeight, err := clienttypes.ParseHeight(attributes[channeltypes.AttributeKeyTimeoutHeight])
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet timeout height: %w", err)
+		}
+		timeoutTimestamp, err := strconv.ParseUint(attributes[channeltypes.AttributeKeyTimeoutTimestamp], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet timeout timestamp: %w", err)
+		}
+
+		packets = append(packets, channeltypes.NewPacket(
+			data,
+			sequence,
+			attributes[channeltypes.AttributeKeySrcPort],
+			attributes[channeltypes.AttributeKeySrcChannel],
+			attributes[channeltypes.AttributeKeyDstPort],
+			attributes[channeltypes.AttributeKeyDstChannel],
+			timeoutHeight,
+			timeoutTimestamp,
+		))
+	}
+	return packets, nil
+}
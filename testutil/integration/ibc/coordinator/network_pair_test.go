@@ -0,0 +1,60 @@
+package coordinator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	"github.com/evmos/evmos/v16/utils"
+)
+
+func TestNetworkPairTransfer(t *testing.T) {
+	chainA := utils.TestnetChainID + "-1"
+	chainB := utils.MainnetChainID + "-1"
+	pair := NewNetworkPair(t, chainA, chainB)
+	sender := pair.KeyringA.GetAccAddr(0)
+	receiver := pair.KeyringB.GetAccAddr(0)
+	amount := sdktypes.NewCoin(utils.BaseDenom, sdktypes.NewInt(1e18))
+
+	senderBalance, err := pair.GetBalance(chainA, sender, utils.BaseDenom)
+	require.NoError(t, err)
+
+	endpointA, endpointB := pair.Connection.EndpointA, pair.Connection.EndpointB
+	msg := transfertypes.NewMsgTransfer(
+		endpointA.PortID,
+		endpointA.ChannelID,
+		amount,
+		sender.String(),
+		receiver.String(),
+		clienttypes.NewHeight(clienttypes.ParseChainID(chainB), 1000),
+		0,
+		"",
+	)
+	_, err = pair.SendMsgs(chainA, msg)
+	require.NoError(t, err)
+	require.Len(t, pair.PendingPackets(), 1)
+
+	// the transferred amount is escrowed on the source network
+	balance, err := pair.GetBalance(chainA, sender, utils.BaseDenom)
+	require.NoError(t, err)
+	require.True(t, balance.Amount.LTE(senderBalance.Amount.Sub(amount.Amount)))
+
+	require.NoError(t, pair.RelayPackets())
+	require.Empty(t, pair.PendingPackets())
+
+	// the receiver gets the vouchers of the transferred amount on the destination network
+	voucherDenom := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(endpointB.PortID, endpointB.ChannelID, utils.BaseDenom),
+	).IBCDenom()
+	voucher, err := pair.GetBalance(chainB, receiver, voucherDenom)
+	require.NoError(t, err)
+	require.Equal(t, amount.Amount, voucher.Amount)
+
+	require.NoError(t, pair.CommitBlocks(1))
+
+	_, err = pair.SendMsgs("unknown-1", msg)
+	require.Error(t, err)
+}
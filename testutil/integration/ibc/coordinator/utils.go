@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	ibctesting "github.com/cosmos/ibc-go/v7/testing"
+	evmosibc "github.com/evmos/evmos/v16/ibc/testing"
 	"github.com/evmos/evmos/v16/testutil/integration/common/network"
 )
 
@@ -38,3 +39,23 @@ func mergeMaps(m1, m2 map[string]*ibctesting.TestChain) map[string]*ibctesting.T
 	}
 	return m1
 }
+
+// newIBCConnection returns the identifiers of the given path between the chains a and b.
+func newIBCConnection(a, b string, path *evmosibc.Path) IBCConnection {
+	return IBCConnection{
+		EndpointA: Endpoint{
+			ChainID:      a,
+			ClientID:     path.EndpointA.ClientID,
+			ConnectionID: path.EndpointA.ConnectionID,
+			ChannelID:    path.EndpointA.ChannelID,
+			PortID:       path.EndpointA.ChannelConfig.PortID,
+		},
+		EndpointB: Endpoint{
+			ChainID:      b,
+			ClientID:     path.EndpointB.ClientID,
+			ConnectionID: path.EndpointB.ConnectionID,
+			ChannelID:    path.EndpointB.ChannelID,
+			PortID:       path.EndpointB.ChannelConfig.PortID,
+		},
+	}
+}